@@ -1,7 +1,9 @@
 package env
 
 import (
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"reflect"
 	"strings"
@@ -17,6 +19,28 @@ type Validator interface {
 	Validate() error
 }
 
+// DefaultsLogMode controls how Load reports the fields using their default value.
+type DefaultsLogMode int
+
+const (
+	// DefaultsLogEach logs a warning for each field using its default value.
+	DefaultsLogEach DefaultsLogMode = iota
+	// DefaultsLogSummary logs a single line listing the fields using their default value.
+	DefaultsLogSummary
+	// DefaultsLogNone doesn't log the fields using their default value.
+	DefaultsLogNone
+)
+
+// defaultsLogMode is the mode used by Load to report default values
+var defaultsLogMode = DefaultsLogEach
+
+// SetDefaultsLogMode sets how Load reports the fields using their default value, e.g.
+// DefaultsLogSummary for production boots where defaults are intentional.
+// It should be called before Load.
+func SetDefaultsLogMode(mode DefaultsLogMode) {
+	defaultsLogMode = mode
+}
+
 // Load reads environment variables from the specified config file(s).
 // If no file paths are provided, it uses APP_ENV to determine the appropriate file:
 //
@@ -25,6 +49,8 @@ type Validator interface {
 // - APP_ENV="local" →  config/.env.local, Loads: config/.env
 //
 // Defaults to "local" if APP_ENV is unset or unrecognized.
+// Missing files are skipped, falling back to the system environment variables if none exist,
+// but a file that exists and is malformed is returned as an error.
 // Parses the variables into the provided config struct and validates them if applicable.
 func Load[T any](filePaths ...string) (*T, error) {
 	var config T
@@ -32,9 +58,14 @@ func Load[T any](filePaths ...string) (*T, error) {
 	// Determine which config files to load (use APP_ENV-based defaults if no file is provided)
 	files := getConfigFiles(filePaths)
 
-	// Load environment variables from the config file(s)
-	if err := loadEnvFiles(files); err != nil {
-		log.Info("env: config from system environment variables")
+	// Load environment variables from the config file(s), missing files are skipped
+	loaded, err := loadEnvFiles(files)
+	if err != nil {
+		log.Error("env: invalid config file", "error", err)
+		return nil, err
+	}
+	if loaded == 0 {
+		log.Info("env: no config file found, config from system environment variables")
 	}
 
 	// Parse the environment variables into the config struct
@@ -79,53 +110,118 @@ func getConfigFiles(filePaths []string) []string {
 	}
 }
 
-// loadEnvFiles loads environment variables from the specified configuration files in order.
-// It attempts to load each file and logs warnings if any fail to load.
-// The order in which files are provided determines the priority—later files do not override earlier ones.
-func loadEnvFiles(files []string) error {
-	var loadErrors []string
+// loadEnvFiles loads environment variables from the specified configuration files in order,
+// returning the number of files loaded. Missing files are optional and skipped, but a file that
+// exists and can't be read or parsed is an error, since silently ignoring it would run with a
+// partial config. The order in which files are provided determines the priority—later files
+// do not override earlier ones.
+func loadEnvFiles(files []string) (int, error) {
+	loaded := 0
 
 	// Try loading each file
 	for _, file := range files {
-		if err := godotenv.Load(file); err != nil {
-			loadErrors = append(loadErrors, file)
-			log.Warn("env: failed to load config file, skipping", "file", file)
-		} else {
-			log.Info("env: loaded environment variables from", "file", file)
+		err := godotenv.Load(file)
+		if errors.Is(err, fs.ErrNotExist) {
+			log.Info("env: config file not found, skipping", "file", file)
+			continue
+		}
+		if err != nil {
+			return loaded, fmt.Errorf("failed to load config file %s: %w", file, err)
 		}
-	}
 
-	// If no files were successfully loaded, return an error indicating which files failed
-	if len(loadErrors) > 0 {
-		return fmt.Errorf("failed to load config files: %v", loadErrors)
+		loaded++
+		log.Info("env: loaded environment variables from", "file", file)
 	}
 
-	return nil
+	return loaded, nil
 }
 
 // parseEnvVars parses environment variables into the provided config struct using caarlos0/env.
 func parseEnvVars(config any) error {
 	opts := env.Options{DefaultValueTagName: "default", RequiredIfNoDef: true}
 	if err := env.ParseWithOptions(config, opts); err != nil {
-		return formatEnvParseError(err)
+		return formatEnvParseError(err, envDescriptions(config))
 	}
 	return nil
 }
 
-// formatEnvParseError formats the error to log each missing environment variable
-func formatEnvParseError(err error) error {
-	// Split the error string into individual error variables
-	errorString := err.Error()
+// envDescriptions maps the environment variable names of the config fields to their
+// `desc` tag, e.g. `env:"DB_PASSWORD" desc:"Database password is required for production"`.
+func envDescriptions(config any) map[string]string {
+	descs := make(map[string]string)
+
+	t := reflect.TypeOf(config)
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() == reflect.Struct {
+		collectEnvDescriptions(t, "", descs)
+	}
+
+	return descs
+}
+
+// collectEnvDescriptions adds the descriptions of the fields of t to descs,
+// following nested structs and their `envPrefix` tag like caarlos0/env does.
+func collectEnvDescriptions(t reflect.Type, prefix string, descs map[string]string) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		key := strings.Split(field.Tag.Get("env"), ",")[0]
+		if key == "" {
+			// Nested structs are parsed with their optional prefix
+			ft := field.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct && field.IsExported() {
+				collectEnvDescriptions(ft, prefix+field.Tag.Get("envPrefix"), descs)
+			}
+			continue
+		}
+
+		if desc := field.Tag.Get("desc"); desc != "" {
+			descs[prefix+key] = desc
+		}
+	}
+}
+
+// envErrorKey returns the environment variable name reported by a caarlos0/env error, if any.
+func envErrorKey(err error) string {
+	var notSet env.VarIsNotSetError
+	if errors.As(err, &notSet) {
+		return notSet.Key
+	}
+	var empty env.EmptyVarError
+	if errors.As(err, &empty) {
+		return empty.Key
+	}
+	return ""
+}
+
+// formatEnvParseError formats the error to log each missing environment variable,
+// along with its description when the field has a `desc` tag.
+func formatEnvParseError(err error, descs map[string]string) error {
+	// Split the error into individual variable errors
+	errs := []error{err}
+	var aggregate env.AggregateError
+	if errors.As(err, &aggregate) {
+		errs = aggregate.Errors
+	}
 
 	// format the error to split each variable error on a new line
 	var envErrors []string
-	for _, line := range strings.Split(errorString, ";") {
-		line = strings.TrimSpace(line)
+	for _, e := range errs {
+		line := strings.TrimSpace(e.Error())
 		if line != "" {
 			// format and log env errors
 			line = strings.Replace(line, "\"", "", -1)
 			line = strings.Replace(line, "env: ", "", -1)
-			log.Error("env: parsing failed", "error", line)
+			if desc, found := descs[envErrorKey(e)]; found {
+				log.Error("env: parsing failed", "error", line, "description", desc)
+			} else {
+				log.Error("env: parsing failed", "error", line)
+			}
 			envErrors = append(envErrors, line)
 		}
 	}
@@ -149,11 +245,17 @@ func validateConfig[T any](config *T) error {
 	return nil
 }
 
-// Helper function to check and log if the default value is used for all fields in the struct
+// Helper function to check and log if the default value is used for all fields in the struct,
+// according to the configured DefaultsLogMode.
 func checkAndLogDefaultValues[T any](config *T) {
+	if defaultsLogMode == DefaultsLogNone {
+		return
+	}
+
 	v := reflect.ValueOf(config).Elem()
 	t := v.Type()
 
+	var names []string
 	for i := 0; i < v.NumField(); i++ {
 		field := v.Field(i)
 		fieldType := t.Field(i)
@@ -163,8 +265,17 @@ func checkAndLogDefaultValues[T any](config *T) {
 		if defaultValueTag != "" {
 			// Compare the field value to its default value
 			if fmt.Sprintf("%v", field.Interface()) == defaultValueTag {
+				if defaultsLogMode == DefaultsLogSummary {
+					names = append(names, fieldType.Tag.Get("env"))
+					continue
+				}
 				log.Warn("env: using default value for env", "name", fieldType.Tag.Get("env"), "value", field.Interface())
 			}
 		}
 	}
+
+	// Log a single line for all the defaulted fields
+	if len(names) > 0 {
+		log.Info("env: using default values for env", "names", names)
+	}
 }
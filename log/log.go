@@ -5,7 +5,10 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/fatih/color"
@@ -52,10 +55,25 @@ type Config struct {
 	// WithCaller specifies whether to include the caller information in the log output.
 	// Default is false (caller information is not included).
 	WithCaller bool `env:"LOG_CALLER" default:"false"`
+
+	// Output specifies where the logs are written.
+	// Available values: "stdout", "journald" or "syslog" (default: "stdout").
+	// journald and syslog are only available on unix systems.
+	Output string `env:"LOG_OUTPUT" default:"stdout"`
+
+	// CustomLevels defines additional level names mapped onto the standard levels used
+	// for filtering, e.g. {"NOTICE": 1, "CRITICAL": 4} (INFO=1, WARN=2, ERROR=3, FATAL=4).
+	// Events logged with Logger.Log at a custom level carry its name in the level field,
+	// and custom names are accepted by Level.
+	CustomLevels map[string]int `env:"LOG_CUSTOM_LEVELS" default:""`
+
+	// CallerSkip is the number of additional stack frames to skip when reporting the caller.
+	// Set it to the number of helper layers wrapping the logger (default: 0).
+	CallerSkip int `env:"LOG_CALLER_SKIP" default:"0"`
 }
 
 func (c *Config) validate() error {
-	if !isValidLogLevel(c.Level) {
+	if !isValidLogLevel(c.Level) && !c.isCustomLevel(c.Level) {
 		defaultLogger.Warn("config: Invalid LogLevel, defaulting to INFO", "current_value", c.Level)
 		c.Level = "INFO"
 	}
@@ -63,10 +81,22 @@ func (c *Config) validate() error {
 		defaultLogger.Warn("config: Invalid LogFormat, defaulting to TEXT", "current_value", c.Format)
 		c.Format = "text"
 	}
+	if !isValidLogOutput(c.Output) {
+		defaultLogger.Warn("config: Invalid LogOutput, defaulting to STDOUT", "current_value", c.Output)
+		c.Output = "stdout"
+	}
+	if c.CallerSkip < 0 {
+		defaultLogger.Warn("config: Invalid CallerSkip, defaulting to 0", "current_value", c.CallerSkip)
+		c.CallerSkip = 0
+	}
 	return nil
 }
 
 func (c *Config) level() Level {
+	if lvl, ok := c.customLevels()[strings.ToUpper(c.Level)]; ok {
+		return lvl
+	}
+
 	switch c.Level {
 	case "TRACE":
 		return TraceLevel
@@ -91,6 +121,20 @@ func (c *Config) level() Level {
 	}
 }
 
+// customLevels returns the custom levels with upper-cased names.
+func (c *Config) customLevels() map[string]Level {
+	levels := make(map[string]Level, len(c.CustomLevels))
+	for name, lvl := range c.CustomLevels {
+		levels[strings.ToUpper(name)] = Level(lvl)
+	}
+	return levels
+}
+
+func (c *Config) isCustomLevel(level string) bool {
+	_, ok := c.customLevels()[strings.ToUpper(level)]
+	return ok
+}
+
 func isValidLogLevel(level string) bool {
 	validLevels := []string{"DEBUG", "INFO", "WARN", "ERROR", "FATAL"}
 	for _, l := range validLevels {
@@ -111,6 +155,16 @@ func isValidLogFormat(format string) bool {
 	return false
 }
 
+func isValidLogOutput(output string) bool {
+	validOutputs := []string{"stdout", "journald", "syslog"}
+	for _, o := range validOutputs {
+		if strings.ToLower(output) == o {
+			return true
+		}
+	}
+	return false
+}
+
 // Info logs general informational messages about application flow or user actions.
 // Use for routine status updates or significant events during normal operations.
 func Info(msg string, args ...any) {
@@ -171,16 +225,104 @@ func FatalContext(ctx context.Context, msg string, args ...any) {
 	defaultLogger.FatalContext(ctx, msg, args...)
 }
 
+// Log logs a message at the named level using the default logger.
+// The level is either a custom level defined in Config.CustomLevels or a standard one.
+func Log(levelName string, msg string, args ...any) {
+	defaultLogger.Log(levelName, msg, args...)
+}
+
+// fieldsKey is the context key of the correlation fields added with ContextWithFields.
+type fieldsKey struct{}
+
+// ContextWithFields returns a copy of ctx carrying the given key-value pairs as
+// correlation fields (e.g. request_id), in addition to the ones already in ctx.
+func ContextWithFields(ctx context.Context, args ...any) context.Context {
+	fields := append(contextFields(ctx), args...)
+	return context.WithValue(ctx, fieldsKey{}, fields)
+}
+
+// contextFields returns a copy of the correlation fields carried by ctx.
+func contextFields(ctx context.Context) []any {
+	fields, _ := ctx.Value(fieldsKey{}).([]any)
+	return append([]any(nil), fields...)
+}
+
+// WithFields returns a logger based on the default logger, pre-populated with the
+// correlation fields carried by ctx. Pass it, or the context, to goroutines spawned by
+// a request so their logs keep the request correlation:
+//
+//	logger := log.WithFields(ctx)
+//	go func() {
+//		logger.Info("job: Export finished")
+//	}()
+func WithFields(ctx context.Context) *Logger {
+	return defaultLogger.WithFields(ctx)
+}
+
 // SetLevel sets the minimum log level.
 // To turn off all logs, set level Disabled.
 func SetLevel(level Level) {
 	defaultLogger.SetLevel(level)
 }
 
+// SetLevelFromString sets the minimum log level of the default logger from its name,
+// accepting the same values as Config.Level (e.g. "DEBUG"), to change verbosity at runtime.
+func SetLevelFromString(s string) error {
+	return defaultLogger.SetLevelFromString(s)
+}
+
+// GetLevel returns the minimum log level of the default logger.
+func GetLevel() Level {
+	return defaultLogger.GetLevel()
+}
+
+// disabled holds the level of the default logger before Disable, restored by Enable
+var disabled struct {
+	sync.Mutex
+	active bool
+	level  Level
+}
+
+// Disable silences the default logger, e.g. in tests, until Enable is called.
+// It is safe to call while other goroutines log, e.g. from parallel tests.
+func Disable() {
+	disabled.Lock()
+	defer disabled.Unlock()
+
+	level := Level(defaultLogger.level.Swap(int32(Disabled)))
+	if !disabled.active {
+		disabled.active = true
+		disabled.level = level
+	}
+}
+
+// Enable restores the level the default logger had before Disable.
+// Like Disable, it is safe to call while other goroutines log.
+func Enable() {
+	disabled.Lock()
+	defer disabled.Unlock()
+
+	if disabled.active {
+		disabled.active = false
+		defaultLogger.SetLevel(disabled.level)
+	}
+}
+
+// AddHook adds a zerolog hook to the default logger.
+func AddHook(hook zerolog.Hook) {
+	defaultLogger.AddHook(hook)
+}
+
+// OnError registers fn to be called for every Error and Fatal log written by the default logger.
+// Useful for forwarding failures to external sinks such as Sentry or an OTLP collector.
+func OnError(fn HookFunc) {
+	defaultLogger.OnError(fn)
+}
+
 // SetDefaultLogger sets the internal default logger.
 func SetDefaultLogger(l *Logger) {
 	defaultLogger = l
-	defaultLogger.skip = 2
+	defaultLogger.skip = defaultLogger.callerDepth()
 }
 
 // Logger defines methods for logging messages at various levels, supporting both standard and
@@ -190,8 +332,82 @@ type Logger struct {
 	skip    int
 	handler zerolog.Logger
 
+	// level is the minimum level of the events written, checked before they reach the handler
+	// so it can be changed at runtime while other goroutines log. It is shared with the
+	// loggers returned by WithFields.
+	level *atomic.Int32
+
+	// callerSkip is the number of additional frames skipped for wrapper layers.
+	callerSkip int
+
 	// rightAlignPrefix controls whether the prefix (before the colon) in the log message should be right-aligned.
 	rightAlignPrefix bool
+
+	// customLevels maps custom level names to the standard levels used for filtering.
+	customLevels map[string]Level
+
+	// hooks are the zerolog hooks run by the handler for every event written. They are shared
+	// with the loggers returned by WithFields, and can be added while other goroutines log.
+	hooks *hookList[zerolog.Hook]
+
+	// errorHooks are called for every Error and Fatal event before it is written.
+	// Like hooks, they are shared with the loggers returned by WithFields.
+	errorHooks *hookList[HookFunc]
+}
+
+// hookList is a list of hooks that can be appended to while other goroutines read it.
+// Appends copy the list, so readers iterate over a snapshot without locking.
+type hookList[T any] struct {
+	mu   sync.Mutex
+	list atomic.Pointer[[]T]
+}
+
+// add appends hook to the list.
+func (h *hookList[T]) add(hook T) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	list := append(slices.Clone(h.load()), hook)
+	h.list.Store(&list)
+}
+
+// load returns a snapshot of the hooks.
+func (h *hookList[T]) load() []T {
+	if list := h.list.Load(); list != nil {
+		return *list
+	}
+	return nil
+}
+
+// withHooks returns handler running the hooks of list for every event written.
+func withHooks(handler zerolog.Logger, list *hookList[zerolog.Hook]) zerolog.Logger {
+	return handler.Hook(zerolog.HookFunc(func(e *zerolog.Event, level zerolog.Level, msg string) {
+		for _, hook := range list.load() {
+			hook.Run(e, level, msg)
+		}
+	}))
+}
+
+// HookFunc receives the level, message and structured fields of a log event.
+type HookFunc func(level Level, msg string, fields map[string]any)
+
+// NewDiscard returns a logger discarding every message without formatting it,
+// e.g. to pass to code under test without configuring or affecting the default logger.
+func NewDiscard() *Logger {
+	return &Logger{
+		skip:       1,
+		handler:    zerolog.Nop(),
+		level:      newLevel(Disabled),
+		hooks:      new(hookList[zerolog.Hook]),
+		errorHooks: new(hookList[HookFunc]),
+	}
+}
+
+// newLevel returns an atomic minimum level initialized to level.
+func newLevel(level Level) *atomic.Int32 {
+	l := new(atomic.Int32)
+	l.Store(int32(level))
+	return l
 }
 
 // SetGlobalLevel sets the global override for log level. If this
@@ -229,7 +445,7 @@ func NewLogger(c *Config) *Logger {
 	// JSON Logger
 	if c.Format == "json" {
 		// Create JSON formatted logger
-		logger = zerolog.New(os.Stdout).Level(c.level()).With().Timestamp().Logger()
+		logger = zerolog.New(os.Stdout).With().Timestamp().Logger()
 	}
 
 	// Default Console Logger
@@ -242,56 +458,221 @@ func NewLogger(c *Config) *Logger {
 		writer.TimeFormat = time.DateTime
 		writer.FormatCaller = fixedLengthCallerFormatter
 		writer.PartsOrder = textDefaultPartsOrder(c.WithCaller)
-		logger = zerolog.New(writer).Level(c.level()).With().Timestamp().Logger()
+		logger = zerolog.New(writer).With().Timestamp().Logger()
+	}
+
+	// Journald or Syslog Output, always structured regardless of the format
+	if c.Output != "" && c.Output != "stdout" {
+		writer, err := outputWriter(c.Output)
+		if err != nil {
+			logger.Warn().Err(err).Msg("log: failed to open output, falling back to stdout")
+		} else {
+			logger = zerolog.New(writer).With().Timestamp().Logger()
+		}
+	}
+
+	hooks := new(hookList[zerolog.Hook])
+	return &Logger{
+		skip:             1 + c.CallerSkip,
+		handler:          withHooks(logger, hooks),
+		level:            newLevel(c.level()),
+		hooks:            hooks,
+		errorHooks:       new(hookList[HookFunc]),
+		rightAlignPrefix: rightAlignPrefix,
+		callerSkip:       c.CallerSkip,
+		customLevels:     c.customLevels(),
+	}
+}
+
+// SetCallerSkip sets the number of additional stack frames to skip when reporting the caller,
+// so the caller points at the real call site when the logger is wrapped in helper functions.
+func (l *Logger) SetCallerSkip(n int) {
+	l.callerSkip = n
+	l.skip = l.callerDepth()
+}
+
+// callerDepth returns the number of frames to skip, accounting for the package-level
+// functions when l is the default logger.
+func (l *Logger) callerDepth() int {
+	depth := 1 + l.callerSkip
+	if l == defaultLogger {
+		depth++
 	}
+	return depth
+}
 
-	return &Logger{skip: 1, handler: logger, rightAlignPrefix: rightAlignPrefix}
+// WithFields returns a copy of the logger pre-populated with the correlation fields carried by ctx.
+// The copy shares the level of l, so changing it applies to both.
+func (l *Logger) WithFields(ctx context.Context) *Logger {
+	logger := *l
+	logger.skip = 1 + l.callerSkip
+	if fields := contextFields(ctx); len(fields) > 0 {
+		logger.handler = l.handler.With().Fields(fields).Logger()
+	}
+	return &logger
 }
 
+// SetLevel sets the minimum log level. It is safe to call while other goroutines log.
 func (l *Logger) SetLevel(level Level) {
-	l.handler = l.handler.Level(level)
+	l.level.Store(int32(level))
+}
+
+// SetLevelFromString sets the minimum log level from its name, accepting the same values
+// as Config.Level, including the logger's custom levels. It returns an error for unknown names.
+func (l *Logger) SetLevelFromString(s string) error {
+	name := strings.ToUpper(strings.TrimSpace(s))
+	if lvl, ok := l.customLevels[name]; ok {
+		l.SetLevel(lvl)
+		return nil
+	}
+
+	if !isValidLogLevel(name) {
+		return fmt.Errorf("log: invalid level %q", s)
+	}
+
+	l.SetLevel((&Config{Level: name}).level())
+	return nil
+}
+
+// GetLevel returns the minimum log level of the logger.
+func (l *Logger) GetLevel() Level {
+	return Level(l.level.Load())
+}
+
+// enabled reports whether events at level pass the minimum level of the logger.
+func (l *Logger) enabled(level Level) bool {
+	return level >= l.GetLevel()
+}
+
+// AddHook adds a zerolog hook that runs for every event written by the logger.
+// It is safe to call while other goroutines log, though hooks are meant to be added during initialization.
+func (l *Logger) AddHook(hook zerolog.Hook) {
+	l.hooks.add(hook)
+}
+
+// OnError registers fn to be called for every Error and Fatal event.
+// Hooks run before the event is written, so they also fire for Fatal logs that exit the process.
+// Like AddHook, it is safe to call while other goroutines log.
+func (l *Logger) OnError(fn HookFunc) {
+	l.errorHooks.add(fn)
+}
+
+// runErrorHooks calls the registered error hooks if level is Error or above and enabled.
+func (l *Logger) runErrorHooks(level Level, msg string, args []any) {
+	hooks := l.errorHooks.load()
+	if len(hooks) == 0 || level < ErrorLevel || !l.enabled(level) || level < zerolog.GlobalLevel() {
+		return
+	}
+
+	fields := fieldsMap(args)
+	for _, fn := range hooks {
+		fn(level, msg, fields)
+	}
 }
 
 func (l *Logger) Debug(msg string, args ...any) {
+	if !l.enabled(DebugLevel) {
+		return
+	}
 	l.handler.Debug().Fields(args).Caller(l.skip).Msg(l.withPrefixAlignment(msg))
 }
 
 func (l *Logger) DebugContext(ctx context.Context, msg string, args ...any) {
+	if !l.enabled(DebugLevel) {
+		return
+	}
 	l.handler.Debug().Fields(args).Caller(l.skip).Msg(l.withPrefixAlignment(msg))
 }
 
 func (l *Logger) Info(msg string, args ...any) {
+	if !l.enabled(InfoLevel) {
+		return
+	}
 	l.handler.Info().Fields(args).Caller(l.skip).Msg(l.withPrefixAlignment(msg))
 }
 
 func (l *Logger) InfoContext(ctx context.Context, msg string, args ...any) {
+	if !l.enabled(InfoLevel) {
+		return
+	}
 	l.handler.Info().Fields(args).Caller(l.skip).Msg(l.withPrefixAlignment(msg))
 }
 
 func (l *Logger) Warn(msg string, args ...any) {
+	if !l.enabled(WarnLevel) {
+		return
+	}
 	l.handler.Warn().Fields(args).Caller(l.skip).Msg(l.withPrefixAlignment(msg))
 }
 
 func (l *Logger) WarnContext(ctx context.Context, msg string, args ...any) {
+	if !l.enabled(WarnLevel) {
+		return
+	}
 	l.handler.Warn().Fields(args).Caller(l.skip).Msg(l.withPrefixAlignment(msg))
 }
 
 func (l *Logger) Error(msg string, args ...any) {
+	if !l.enabled(ErrorLevel) {
+		return
+	}
+	l.runErrorHooks(ErrorLevel, msg, args)
 	l.handler.Error().Fields(args).Caller(l.skip).Msg(l.withPrefixAlignment(msg))
 }
 
 func (l *Logger) ErrorContext(ctx context.Context, msg string, args ...any) {
+	if !l.enabled(ErrorLevel) {
+		return
+	}
+	l.runErrorHooks(ErrorLevel, msg, args)
 	l.handler.Error().Fields(args).Caller(l.skip).Msg(l.withPrefixAlignment(msg))
 }
 
 func (l *Logger) Fatal(msg string, args ...any) {
+	if !l.enabled(FatalLevel) {
+		return
+	}
+	l.runErrorHooks(FatalLevel, msg, args)
 	l.handler.Fatal().Fields(args).Caller(l.skip).Msg(l.withPrefixAlignment(msg))
 }
 
 func (l *Logger) FatalContext(ctx context.Context, msg string, args ...any) {
+	if !l.enabled(FatalLevel) {
+		return
+	}
+	l.runErrorHooks(FatalLevel, msg, args)
 	l.handler.Fatal().Fields(args).Caller(l.skip).Msg(l.withPrefixAlignment(msg))
 }
 
+// Log logs a message at the named level, which is either a custom level defined in
+// Config.CustomLevels (e.g. "NOTICE") or a standard one (e.g. "WARN").
+// Custom levels are filtered like the standard level they map to, and their name is
+// written in the level field. Unknown names are logged at INFO with their name as level.
+func (l *Logger) Log(levelName string, msg string, args ...any) {
+	name := strings.ToUpper(levelName)
+
+	lvl, custom := l.customLevels[name]
+	if !custom {
+		std := (&Config{Level: name}).level()
+		if std != InfoLevel || name == "INFO" {
+			if !l.enabled(std) {
+				return
+			}
+			l.runErrorHooks(std, msg, args)
+			l.handler.WithLevel(std).Fields(args).Caller(l.skip).Msg(l.withPrefixAlignment(msg))
+			return
+		}
+		lvl = InfoLevel
+	}
+
+	// Filter like the mapped level, then write the custom name as the level field
+	if !l.enabled(lvl) || lvl < zerolog.GlobalLevel() {
+		return
+	}
+	l.runErrorHooks(lvl, msg, args)
+	l.handler.Log().Str(zerolog.LevelFieldName, strings.ToLower(name)).Fields(args).Caller(l.skip).Msg(l.withPrefixAlignment(msg))
+}
+
 // withPrefixAlignment aligns the prefix part of the log message to the right and appends the actual log message.
 func (l *Logger) withPrefixAlignment(message string) string {
 	if !l.rightAlignPrefix {
@@ -330,6 +711,17 @@ func (l *Logger) withPrefixAlignment(message string) string {
 	return message
 }
 
+// fieldsMap converts key-value pairs into a map, the same way zerolog reads them.
+func fieldsMap(args []any) map[string]any {
+	fields := make(map[string]any, len(args)/2)
+	for i := 0; i+1 < len(args); i += 2 {
+		if key, ok := args[i].(string); ok {
+			fields[key] = args[i+1]
+		}
+	}
+	return fields
+}
+
 // fixedLengthCallerFormatter formats the caller with the package name and file name, left-aligned and colored.
 func fixedLengthCallerFormatter(caller interface{}) string {
 	// Convert the caller (which is an interface) to a string (which is the full file path)
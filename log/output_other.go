@@ -0,0 +1,13 @@
+//go:build windows || plan9
+
+package log
+
+import (
+	"fmt"
+	"io"
+)
+
+// outputWriter is not supported on this platform, journald and syslog are only available on unix.
+func outputWriter(output string) (io.Writer, error) {
+	return nil, fmt.Errorf("log: output %q is not supported on this platform", output)
+}
@@ -0,0 +1,30 @@
+//go:build !windows && !plan9
+
+package log
+
+import (
+	"fmt"
+	"io"
+	"log/syslog"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/journald"
+)
+
+// outputWriter returns the writer for the journald or syslog output.
+// Log levels are mapped to journal priorities and syslog severities,
+// and structured fields are kept as journal fields.
+func outputWriter(output string) (io.Writer, error) {
+	switch output {
+	case "journald":
+		return journald.NewJournalDWriter(), nil
+	case "syslog":
+		w, err := syslog.New(syslog.LOG_INFO|syslog.LOG_USER, "")
+		if err != nil {
+			return nil, err
+		}
+		return zerolog.SyslogLevelWriter(w), nil
+	default:
+		return nil, fmt.Errorf("log: unsupported output %q", output)
+	}
+}
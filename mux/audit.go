@@ -0,0 +1,60 @@
+package mux
+
+import (
+	"net/http"
+	"time"
+)
+
+// AuditEntry is a user-attributed record of a state-changing request.
+type AuditEntry struct {
+	Timestamp time.Time `json:"timestamp"`
+	User      string    `json:"user"`
+	Method    string    `json:"method"`
+	Path      string    `json:"path"`
+	Status    int       `json:"status"`
+	RequestID string    `json:"request_id"`
+}
+
+// AuditLog returns a middleware calling fn with an AuditEntry after each mutating request
+// (POST, PUT, PATCH and DELETE), e.g. to persist an audit trail to a database.
+// The user is read from Context.CurrentUser, so the middleware should be registered
+// after the authentication middleware setting it.
+//
+// Errors returned by the handler are passed on unchanged to the outer middleware and
+// the router, and the entry records the status the router answers them with.
+func AuditLog(fn func(AuditEntry)) MiddlewareFunc {
+	return func(next Handler) Handler {
+		return HandlerFunc(func(ctx *Context) error {
+			if !isMutatingMethod(ctx.Method()) {
+				return next.Handle(ctx)
+			}
+
+			err := next.Handle(ctx)
+
+			status := ctx.rsp.statusOrDefault(ctx.Status())
+			if err != nil && !ctx.rsp.Written() {
+				status, _ = pendingResponse(err)
+			}
+
+			fn(AuditEntry{
+				Timestamp: time.Now(),
+				User:      ctx.CurrentUser(),
+				Method:    ctx.Method(),
+				Path:      ctx.req.URL.Path,
+				Status:    status,
+				RequestID: ctx.RequestID(),
+			})
+
+			return err
+		})
+	}
+}
+
+// isMutatingMethod reports whether requests with the given method change state.
+func isMutatingMethod(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,55 @@
+package mux
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAuditLogReturnsHandlerError(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		want    int
+		wantErr bool
+	}{
+		{"error", &NotFoundError{}, http.StatusNotFound, true},
+		{"result", Created[string]{Value: "created"}, http.StatusCreated, true},
+		{"no error", nil, http.StatusOK, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var entry AuditEntry
+			h := AuditLog(func(e AuditEntry) { entry = e })(HandlerFunc(func(ctx *Context) error {
+				if tt.err == nil {
+					return ctx.OK(M{})
+				}
+				return tt.err
+			}))
+
+			ctx, _ := NewTestContext(http.MethodPost, "/users", nil)
+			err := h.Handle(ctx)
+
+			if tt.wantErr && !errors.Is(err, tt.err) {
+				t.Errorf("middleware returned %v, want the handler error %v", err, tt.err)
+			}
+			if entry.Status != tt.want {
+				t.Errorf("entry status = %d, want %d", entry.Status, tt.want)
+			}
+		})
+	}
+}
+
+func TestAuditLogStatusMatchesResponse(t *testing.T) {
+	var entry AuditEntry
+	h := AuditLog(func(e AuditEntry) { entry = e })(HandlerFunc(func(ctx *Context) error {
+		return errors.New("database unavailable")
+	}))
+
+	rec := TestHandler(h, httptest.NewRequest(http.MethodDelete, "/users/1", nil))
+	if entry.Status != rec.Code {
+		t.Errorf("entry status = %d, response status = %d", entry.Status, rec.Code)
+	}
+}
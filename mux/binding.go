@@ -1,13 +1,18 @@
 package mux
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"mime"
+	"mime/multipart"
 	"net/http"
+	"reflect"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/go-viper/mapstructure/v2"
 
@@ -16,10 +21,54 @@ import (
 
 type M map[string]any
 
+// bindingErrorFunc is called for each binding error sent to the client
+var bindingErrorFunc func(field, message string)
+
+// OnBindingError sets a function called for each binding error sent to the client,
+// once per field error, or with an empty field for body-level errors.
+// Pass nil to disable it. It should be called during initialization.
+func OnBindingError(fn func(field, message string)) {
+	bindingErrorFunc = fn
+}
+
+// DecoderFunc reads and unmarshals the request body into v, for a content type registered with RegisterDecoder.
+type DecoderFunc func(r *http.Request, v any) error
+
+// decoders maps media types to the decoders registered for them
+var decoders = map[string]DecoderFunc{}
+
+// RegisterDecoder registers the decoder used by Context.Decode for request bodies of the given
+// media type, e.g. "application/msgpack", instead of JSON. The body is limited to Config.MaxBodyBytes,
+// and normalization and validation still run after decoding. Errors other than BindingError are
+// answered with a generic 400. A registered media type is decoded even if it is listed in
+// Config.RawContentTypes. It should be called during initialization.
+func RegisterDecoder(contentType string, fn DecoderFunc) {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType = contentType
+	}
+	decoders[strings.ToLower(strings.TrimSpace(mediaType))] = fn
+}
+
+// registeredDecoder returns the decoder registered for the Content-Type of r and its media type, if any.
+func registeredDecoder(r *http.Request) (DecoderFunc, string) {
+	if len(decoders) == 0 {
+		return nil, ""
+	}
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil {
+		return nil, ""
+	}
+	return decoders[strings.ToLower(mediaType)], mediaType
+}
+
 // BindingError represents errors related to JSON body or URL Query Params bindings.
 type BindingError struct {
 	Message string
 	Errors  map[string]string
+
+	// status overrides the default 400 Bad Request response status
+	status int
 }
 
 // Error implements builtin.error interface
@@ -31,13 +80,64 @@ func newBindingError(format string, a ...any) *BindingError {
 	return &BindingError{Message: fmt.Sprintf(format, a...)}
 }
 
+// EncodeError is returned when a response body can't be marshaled to JSON
+// (e.g. it contains a channel or a function). Nothing is written to the response,
+// so it is answered with a clean 500 Internal Server Error.
+type EncodeError struct {
+	Type string
+	Err  error
+}
+
+// Error implements builtin.error interface
+func (e *EncodeError) Error() string {
+	return fmt.Sprintf("failed to encode response body of type %s: %v", e.Type, e.Err)
+}
+
+// Unwrap returns the underlying marshal error.
+func (e *EncodeError) Unwrap() error {
+	return e.Err
+}
+
+// defaultContentType is the Content-Type of JSON responses unless configured otherwise.
+const defaultContentType = "application/json; charset=utf-8"
+
 // encode writes data to the http response as JSON-encoded
-// and sets the Content-Type header to "application/json"
+// and sets the Content-Type header to the configured default content type,
+// unless it was already set on the response.
+// If status is 0, the status set with Context.SetStatus is used, or 200 OK.
+// The body is marshaled before anything is written, an EncodeError is returned on failure.
 func encode(w http.ResponseWriter, status int, body any, headers http.Header) error {
+	contentType := defaultContentType
+
+	// use the deferred status when no explicit status is given
+	rw, wrapped := w.(*responseWriter)
+	if status == 0 {
+		status = http.StatusOK
+		if wrapped {
+			status = rw.statusOrDefault(0)
+		}
+	}
+	if wrapped && rw.contentType != "" {
+		contentType = rw.contentType
+	}
+
+	// apply the response transformers
+	if wrapped && rw.transform != nil {
+		body = rw.transform(body)
+	}
+
 	// encode body to json
 	b, err := json.Marshal(body)
 	if err != nil {
-		return err
+		log.Error("mux: Failed to encode response body", "type", fmt.Sprintf("%T", body), "error", err)
+		return &EncodeError{Type: fmt.Sprintf("%T", body), Err: err}
+	}
+
+	// strip empty values and convert keys when enabled
+	if wrapped && (rw.compact || rw.renameKey != nil) {
+		if rewritten, err := rewriteJSON(b, rw.compact, rw.renameKey); err == nil {
+			b = rewritten
+		}
 	}
 
 	// add headers
@@ -46,26 +146,62 @@ func encode(w http.ResponseWriter, status int, body any, headers http.Header) er
 	}
 
 	// set response status and content-type header
-	w.Header().Set("Content-Type", "application/json")
+	if w.Header().Get("Content-Type") == "" {
+		w.Header().Set("Content-Type", contentType)
+	}
 	w.WriteHeader(status)
 	_, err = w.Write(b)
 
-	return nil
+	return err
+}
+
+// decodeOptions controls how decode reads the request body.
+type decodeOptions struct {
+	// maxBytes is the maximum size of the body.
+	maxBytes int64
+
+	// allowUnknownFields disables the rejection of fields not defined in v.
+	allowUnknownFields bool
+
+	// useNumber decodes numbers into an interface{} as json.Number instead of float64.
+	useNumber bool
 }
 
 // decode parse JSON-encoded request body and store it in v
-// it returns error if unknown fields found, body limit exceeded 1MB
+// it returns error if unknown fields found (unless allowed), body limit exceeded
 // or body contains invalid JSON syntax, invalid JSON type or invalid field type
-func decode(w http.ResponseWriter, r *http.Request, v any) error {
-	// limit request body to 1MB.
-	maxBytes := 1_048_576
-	r.Body = http.MaxBytesReader(w, r.Body, int64(maxBytes))
+func decode(w http.ResponseWriter, r *http.Request, v any, opts decodeOptions) error {
+	// requests built by hand (e.g. in tests) may have no body at all
+	if r.Body == nil {
+		return newBindingError("request has no body")
+	}
 
-	// init JSON decoder
-	decoder := json.NewDecoder(r.Body)
+	// reject early if the declared body size exceeds the limit
+	if err := checkContentLength(r, opts.maxBytes); err != nil {
+		return err
+	}
+
+	// limit request body to maxBytes.
+	r.Body = http.MaxBytesReader(w, r.Body, opts.maxBytes)
+
+	// use the decoder registered for the content type, if any
+	if fn, mediaType := registeredDecoder(r); fn != nil {
+		return decodeRegistered(fn, mediaType, r, v)
+	}
+
+	// init JSON decoder, keeping a copy of the body read so far to locate syntax errors
+	var buf bytes.Buffer
+	decoder := json.NewDecoder(io.TeeReader(r.Body, &buf))
 
 	// only fields defined in v
-	decoder.DisallowUnknownFields()
+	if !opts.allowUnknownFields {
+		decoder.DisallowUnknownFields()
+	}
+
+	// keep the precision of numbers decoded into interface values
+	if opts.useNumber {
+		decoder.UseNumber()
+	}
 
 	// decode body input and store it in v
 	err := decoder.Decode(v)
@@ -113,7 +249,8 @@ func decode(w http.ResponseWriter, r *http.Request, v any) error {
 
 	// check if it is invalid syntax error
 	if errors.As(err, &syntaxError) {
-		return newBindingError("body contains badly-formed JSON and can not be parsed")
+		line, column := jsonPosition(buf.Bytes(), syntaxError.Offset)
+		return newBindingError("body contains badly-formed JSON at line %d, column %d", line, column)
 	}
 
 	// check if it is invalid type error
@@ -128,13 +265,190 @@ func decode(w http.ResponseWriter, r *http.Request, v any) error {
 	return err
 }
 
+// decodeRegistered decodes the body of r into v with a registered decoder, converting its errors to BindingError.
+func decodeRegistered(fn DecoderFunc, mediaType string, r *http.Request, v any) error {
+	err := fn(r, v)
+	if err == nil {
+		return nil
+	}
+
+	var bindingError *BindingError
+	if errors.As(err, &bindingError) {
+		return err
+	}
+
+	var maxBytesError *http.MaxBytesError
+	if errors.As(err, &maxBytesError) {
+		return newBindingError("body must not exceed %d bytes", maxBytesError.Limit)
+	}
+
+	log.Debug("mux: Failed to decode request body", "content_type", mediaType, "error", err)
+	return newBindingError("body contains malformed %s", mediaType)
+}
+
+// jsonPosition converts a byte offset in data to a 1-based line and column.
+func jsonPosition(data []byte, offset int64) (int, int) {
+	if offset > int64(len(data)) {
+		offset = int64(len(data))
+	}
+	prefix := data[:offset]
+	line := 1 + bytes.Count(prefix, []byte("\n"))
+	column := len(prefix) - (bytes.LastIndexByte(prefix, '\n') + 1)
+	return line, column
+}
+
+// readBody reads the whole request body, limited to maxBytes, regardless of its content type.
+func readBody(w http.ResponseWriter, r *http.Request, maxBytes int64) ([]byte, error) {
+	// requests built by hand (e.g. in tests) may have no body at all
+	if r.Body == nil {
+		return nil, newBindingError("request has no body")
+	}
+
+	// reject early if the declared body size exceeds the limit
+	if err := checkContentLength(r, maxBytes); err != nil {
+		return nil, err
+	}
+
+	// limit request body to maxBytes.
+	r.Body = http.MaxBytesReader(w, r.Body, maxBytes)
+
+	b, err := io.ReadAll(r.Body)
+	if err != nil {
+		var maxBytesError *http.MaxBytesError
+		if errors.As(err, &maxBytesError) {
+			return nil, newBindingError("body must not exceed %d bytes", maxBytesError.Limit)
+		}
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// checkContentLength rejects the request with 413 Request Entity Too Large if its
+// Content-Length exceeds maxBytes, before any of the body is read.
+// Chunked or unknown-length bodies are left to the MaxBytesReader enforcement.
+func checkContentLength(r *http.Request, maxBytes int64) error {
+	if r.ContentLength > maxBytes {
+		err := newBindingError("body must not exceed %d bytes", maxBytes)
+		err.status = http.StatusRequestEntityTooLarge
+		return err
+	}
+	return nil
+}
+
+// isRawContentType reports whether the request content type is one of the given raw media types.
+func isRawContentType(r *http.Request, rawTypes []string) bool {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil {
+		return false
+	}
+	for _, t := range rawTypes {
+		if strings.EqualFold(mediaType, strings.TrimSpace(t)) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkRawContentType rejects the request with 415 Unsupported Media Type if its content type is
+// one of the given raw media types, whose body is read with Context.ReadAll rather than decoded.
+// Media types with a registered decoder are decoded with it instead.
+func checkRawContentType(r *http.Request, rawTypes []string) error {
+	if !isRawContentType(r, rawTypes) {
+		return nil
+	}
+	if fn, _ := registeredDecoder(r); fn != nil {
+		return nil
+	}
+
+	err := newBindingError("body of content type %q can not be decoded", r.Header.Get("Content-Type"))
+	err.status = http.StatusUnsupportedMediaType
+	return err
+}
+
 // decodeURL is a helper function that processes the request query parameters.
 func decodeURL(r *http.Request, v any) error {
-	// Parse URL query parameters
-	query := r.URL.Query()
+	// requests built by hand (e.g. in tests) may have no URL at all
+	if r.URL == nil {
+		return newBindingError("request has no URL")
+	}
+
+	// Decode query params into the given struct
+	return decodeMap(queryParams(r), v, "query", "Query Params Decoding Failed")
+}
+
+// queryParams returns the URL query parameters, single values as strings and repeated ones as slices.
+func queryParams(r *http.Request) map[string]any {
+	params := make(map[string]any)
+	if r.URL == nil {
+		return params
+	}
+
+	for key, values := range r.URL.Query() {
+		if len(values) == 1 {
+			params[key] = values[0]
+		} else {
+			params[key] = values
+		}
+	}
+	return params
+}
+
+// hasBody reports whether the request carries a body to decode.
+func hasBody(r *http.Request) bool {
+	return r.Body != nil && r.Body != http.NoBody && r.ContentLength != 0
+}
+
+// decodePath binds the request path values into v using the `path` tag.
+func decodePath(r *http.Request, v any) error {
 	params := make(map[string]any)
+	for _, name := range tagNames(v, "path") {
+		if value := r.PathValue(name); value != "" {
+			params[name] = value
+		}
+	}
+
+	// Decode into the given struct
+	return decodeMap(params, v, "path", "Path Params Decoding Failed")
+}
+
+// tagNames returns the names set with the given tag on the top-level fields of the struct v points to.
+func tagNames(v any, tagName string) []string {
+	t := reflect.TypeOf(v)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil
+	}
 
-	for key, values := range query {
+	var names []string
+	for i := 0; i < t.NumField(); i++ {
+		name := strings.Split(t.Field(i).Tag.Get(tagName), ",")[0]
+		if name != "" && name != "-" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// onlyTagged returns the params matching a name set with the given tag on v,
+// so untagged fields are not filled by name from another source.
+func onlyTagged(params map[string]any, v any, tagName string) map[string]any {
+	tagged := make(map[string]any)
+	for _, name := range tagNames(v, tagName) {
+		if value, found := params[name]; found {
+			tagged[name] = value
+		}
+	}
+	return tagged
+}
+
+// decodeHeader binds the request headers into v using the `header` tag.
+// Header names are matched case-insensitively.
+func decodeHeader(r *http.Request, v any) error {
+	params := make(map[string]any)
+	for key, values := range r.Header {
 		if len(values) == 1 {
 			params[key] = values[0]
 		} else {
@@ -143,13 +457,112 @@ func decodeURL(r *http.Request, v any) error {
 	}
 
 	// Decode into the given struct
+	return decodeMap(params, v, "header", "Header Decoding Failed")
+}
+
+// decodeForm parses the request form and binds text fields and uploaded files into v using the `form` tag.
+func decodeForm(r *http.Request, v any, maxMemory int64) error {
+	// Parse multipart forms, falling back to url-encoded forms
+	err := r.ParseMultipartForm(maxMemory)
+	if errors.Is(err, http.ErrNotMultipart) {
+		err = r.ParseForm()
+	}
+	if err != nil {
+		return newBindingError("body contains a badly-formed form: %v", err)
+	}
+
+	params := make(map[string]any)
+	for key, values := range r.PostForm {
+		if len(values) == 1 {
+			params[key] = values[0]
+		} else {
+			params[key] = values
+		}
+	}
+
+	// Decode text fields into the given struct
+	if err := decodeMap(params, v, "form", "Form Decoding Failed"); err != nil {
+		return err
+	}
+
+	// Bind uploaded files
+	if r.MultipartForm != nil {
+		bindFormFiles(reflect.ValueOf(v), r.MultipartForm.File)
+	}
+
+	return nil
+}
+
+var (
+	fileHeaderType  = reflect.TypeOf((*multipart.FileHeader)(nil))
+	fileHeadersType = reflect.TypeOf([]*multipart.FileHeader(nil))
+)
+
+// bindFormFiles sets *multipart.FileHeader and []*multipart.FileHeader fields tagged with `form`.
+func bindFormFiles(v reflect.Value, files map[string][]*multipart.FileHeader) {
+	v = reflect.Indirect(v)
+	if v.Kind() != reflect.Struct {
+		return
+	}
+
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		// Recurse into embedded structs
+		if field.Anonymous {
+			bindFormFiles(v.Field(i), files)
+			continue
+		}
+
+		name := strings.Split(field.Tag.Get("form"), ",")[0]
+		if name == "" || name == "-" || len(files[name]) == 0 {
+			continue
+		}
+
+		switch field.Type {
+		case fileHeaderType:
+			v.Field(i).Set(reflect.ValueOf(files[name][0]))
+		case fileHeadersType:
+			v.Field(i).Set(reflect.ValueOf(files[name]))
+		}
+	}
+}
+
+// decodeMap decodes params into v using mapstructure with weakly typed input.
+// Field errors are reported in a BindingError with the given message.
+func decodeMap(params map[string]any, v any, tagName, message string) error {
 	decoderConfig := &mapstructure.DecoderConfig{
 		Result:           v,
 		Metadata:         nil,
-		TagName:          "query",
+		TagName:          tagName,
+		WeaklyTypedInput: true,
+	}
+
+	return decodeMapWith(params, decoderConfig, message)
+}
+
+// decodeWeakJSON decodes the JSON body params into v for DecodeWeak, configuring mapstructure
+// to match encoding/json: embedded structs are flattened and RFC 3339 strings are decoded
+// into time.Time. params must be decoded with json.Number to keep the precision of integers.
+func decodeWeakJSON(params map[string]any, v any) error {
+	decoderConfig := &mapstructure.DecoderConfig{
+		Result:           v,
+		TagName:          "json",
 		WeaklyTypedInput: true,
+		Squash:           true,
+		DecodeHook:       mapstructure.StringToTimeHookFunc(time.RFC3339),
 	}
 
+	return decodeMapWith(params, decoderConfig, "Body Decoding Failed")
+}
+
+// decodeMapWith decodes params with the given mapstructure config.
+// Field errors are reported in a BindingError with the given message.
+func decodeMapWith(params map[string]any, decoderConfig *mapstructure.DecoderConfig, message string) error {
 	decoder, err := mapstructure.NewDecoder(decoderConfig)
 	if err != nil {
 		return &BindingError{Message: err.Error()}
@@ -158,7 +571,7 @@ func decodeURL(r *http.Request, v any) error {
 	if err := decoder.Decode(params); err != nil {
 		prefix := "decoding failed due to the following error(s):\n\n"
 		fError := mapstructFieldErrors(strings.Replace(err.Error(), prefix, "", -1))
-		return &BindingError{Message: "Query Params Decoding Failed", Errors: fError}
+		return &BindingError{Message: message, Errors: fError}
 	}
 
 	return nil
@@ -195,14 +608,28 @@ func extractAndRemove(input string) (string, string) {
 	return "", input
 }
 
-func sendDecodeErrorResponse(ctx *Context, e *BindingError) {
+// reportBindingError calls the function set with OnBindingError for each error of e, if any.
+func reportBindingError(e *BindingError) {
+	if bindingErrorFunc == nil {
+		return
+	}
+	if len(e.Errors) == 0 {
+		bindingErrorFunc("", e.Message)
+	}
+	for field, message := range e.Errors {
+		bindingErrorFunc(field, message)
+	}
+}
+
+// decodeErrorResponse builds the response of a binding error, BadRequest unless it has its own status.
+func decodeErrorResponse(e *BindingError) ErrorResponse {
 	response := ErrorResponse{}
 	response.Errors = e.Errors
 	response.Message = e.Error()
-	response.Error = "DECODE_ERROR"
+	response.Error = CodeDecodeError
 	response.Status = http.StatusBadRequest
-	if err := ctx.BadRequest(response); err != nil {
-		log.Error("binding: failed to respond", "error", err)
-		ctx.internalServerError()
+	if e.status != 0 {
+		response.Status = e.status
 	}
+	return response
 }
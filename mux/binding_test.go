@@ -0,0 +1,99 @@
+package mux
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// newNilBodyContext returns a Context for a JSON POST request whose Body is nil,
+// like requests built by hand rather than with httptest.NewRequest.
+func newNilBodyContext() *Context {
+	ctx, _ := NewTestContext(http.MethodPost, "/users", nil)
+	ctx.req.Header.Set("Content-Type", "application/json")
+	ctx.req.Body = nil
+	return ctx
+}
+
+func TestDecodeNilBody(t *testing.T) {
+	ctx := newNilBodyContext()
+
+	var v struct {
+		Name string `json:"name"`
+	}
+	err := ctx.Decode(&v)
+
+	var bindingErr *BindingError
+	if !errors.As(err, &bindingErr) {
+		t.Fatalf("Decode with a nil body: got %v, want a BindingError", err)
+	}
+}
+
+func TestReadAllNilBody(t *testing.T) {
+	ctx := newNilBodyContext()
+
+	_, err := ctx.ReadAll()
+
+	var bindingErr *BindingError
+	if !errors.As(err, &bindingErr) {
+		t.Fatalf("ReadAll with a nil body: got %v, want a BindingError", err)
+	}
+}
+
+func TestDecodeRawContentType(t *testing.T) {
+	type user struct {
+		Name string `json:"name" validate:"required"`
+	}
+
+	tests := []struct {
+		name     string
+		rawTypes []string
+		want     int
+	}{
+		{"not raw", nil, http.StatusBadRequest},
+		{"raw", []string{"text/csv"}, http.StatusUnsupportedMediaType},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, _ := NewTestContext(http.MethodPost, "/users", strings.NewReader(`{}`))
+			ctx.req.Header.Set("Content-Type", "text/csv")
+			ctx.config.RawContentTypes = tt.rawTypes
+
+			err := ctx.Decode(&user{})
+			if err == nil {
+				t.Fatal("Decode returned nil, want an error")
+			}
+			if _, status := BuildErrorResponse(err); status != tt.want {
+				t.Errorf("Decode error status = %d, want %d", status, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeRegisteredRawContentType(t *testing.T) {
+	type row struct {
+		Name string `json:"name" validate:"required"`
+	}
+
+	called := false
+	RegisterDecoder("text/csv", func(r *http.Request, v any) error {
+		called = true
+		v.(*row).Name = "Ada"
+		return nil
+	})
+	defer delete(decoders, "text/csv")
+
+	ctx, _ := NewTestContext(http.MethodPost, "/rows", strings.NewReader("name\nAda\n"))
+	ctx.req.Header.Set("Content-Type", "text/csv")
+	ctx.config.RawContentTypes = []string{"text/csv"}
+
+	var v row
+	if err := ctx.Decode(&v); err != nil {
+		t.Fatalf("Decode returned %v", err)
+	}
+	if !called || v.Name != "Ada" {
+		t.Errorf("registered decoder called = %v, decoded %+v", called, v)
+	}
+}
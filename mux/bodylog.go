@@ -0,0 +1,173 @@
+package mux
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/obadmatar/base/log"
+)
+
+// BodyLoggerOptions configures the BodyLogger middleware.
+type BodyLoggerOptions struct {
+	// MaxBytes is the maximum size of a body to log, larger bodies are logged by size only (default: 4096).
+	MaxBytes int
+
+	// RedactFields lists JSON field names whose values are replaced with "[REDACTED]" at any depth,
+	// matched case-insensitively (default: password, token, secret, authorization, api_key,
+	// access_token, refresh_token, credit_card).
+	RedactFields []string
+}
+
+// defaultRedactFields are the JSON fields redacted when BodyLoggerOptions.RedactFields is empty
+var defaultRedactFields = []string{
+	"password", "token", "secret", "authorization", "api_key", "access_token", "refresh_token", "credit_card",
+}
+
+// BodyLogger returns a middleware logging the request and response bodies at debug level,
+// for debugging integration issues. Only JSON bodies up to MaxBytes are logged, with the
+// configured fields redacted; other bodies are logged by size only. The request body is
+// re-buffered so handlers read it unchanged. Nothing is captured unless the log level is
+// debug, and WebSocket and SSE requests are never logged.
+//
+// Errors returned by the handler are passed on unchanged to the outer middleware and the
+// router, and the logged status and response body are the ones the router answers them with.
+func BodyLogger(opts BodyLoggerOptions) MiddlewareFunc {
+	if opts.MaxBytes <= 0 {
+		opts.MaxBytes = 4096
+	}
+	if len(opts.RedactFields) == 0 {
+		opts.RedactFields = defaultRedactFields
+	}
+
+	redact := make(map[string]bool, len(opts.RedactFields))
+	for _, field := range opts.RedactFields {
+		redact[strings.ToLower(field)] = true
+	}
+
+	return func(next Handler) Handler {
+		return HandlerFunc(func(ctx *Context) error {
+			if log.GetLevel() > log.DebugLevel || ctx.IsWebSocket() || ctx.IsSSE() {
+				return next.Handle(ctx)
+			}
+
+			// Peek at the request body without consuming it
+			var reqBody []byte
+			if r := ctx.req; r.Body != nil && r.Body != http.NoBody {
+				var err error
+				reqBody, err = io.ReadAll(io.LimitReader(r.Body, int64(opts.MaxBytes)+1))
+				if err != nil {
+					log.Debug("mux: Failed to read request body for logging", "error", err)
+				}
+				r.Body = struct {
+					io.Reader
+					io.Closer
+				}{io.MultiReader(bytes.NewReader(reqBody), r.Body), r.Body}
+			}
+
+			// Capture the response body
+			capture := &captureWriter{ResponseWriter: ctx.rsp.ResponseWriter, max: opts.MaxBytes}
+			ctx.rsp.ResponseWriter = capture
+			defer func() { ctx.rsp.ResponseWriter = capture.ResponseWriter }()
+
+			err := next.Handle(ctx)
+
+			// The router answers errors after the middleware returns, log that response instead
+			status := ctx.rsp.statusOrDefault(ctx.Status())
+			rspBody, rspSize := capture.buf.Bytes(), capture.size
+			if err != nil && !ctx.rsp.Written() {
+				var body any
+				status, body = pendingResponse(err)
+				rspBody = nil
+				if body != nil {
+					rspBody, _ = json.Marshal(body)
+				}
+				rspSize = len(rspBody)
+			}
+
+			log.Debug("mux: Request and response bodies",
+				"method", ctx.Method(),
+				"url", ctx.URI(),
+				"request_id", ctx.RequestID(),
+				"status", status,
+				"request_body", formatLoggedBody(reqBody, len(reqBody), opts.MaxBytes, redact),
+				"response_body", formatLoggedBody(rspBody, rspSize, opts.MaxBytes, redact),
+			)
+
+			return err
+		})
+	}
+}
+
+// captureWriter copies up to max bytes of the response body written through it.
+type captureWriter struct {
+	http.ResponseWriter
+	buf  bytes.Buffer
+	max  int
+	size int
+}
+
+// Write copies b to the capture buffer within its limit, then writes it to the underlying writer.
+func (w *captureWriter) Write(b []byte) (int, error) {
+	if room := w.max + 1 - w.buf.Len(); room > 0 {
+		w.buf.Write(b[:min(room, len(b))])
+	}
+	w.size += len(b)
+	return w.ResponseWriter.Write(b)
+}
+
+// Flush sends any buffered data to the client, if the underlying writer supports it.
+func (w *captureWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Unwrap returns the underlying http.ResponseWriter, used by http.ResponseController.
+func (w *captureWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// formatLoggedBody returns the redacted JSON body, or a size summary for bodies that
+// are too large or not JSON, since they can't be redacted.
+func formatLoggedBody(body []byte, size, max int, redact map[string]bool) string {
+	if size == 0 {
+		return ""
+	}
+	if size > max {
+		return fmt.Sprintf("[%d+ bytes, not logged]", max)
+	}
+
+	var v any
+	if err := json.Unmarshal(body, &v); err != nil {
+		return fmt.Sprintf("[%d bytes non-JSON, not logged]", size)
+	}
+
+	b, err := json.Marshal(redactFields(v, redact))
+	if err != nil {
+		return fmt.Sprintf("[%d bytes, not logged]", size)
+	}
+	return string(b)
+}
+
+// redactFields replaces the values of the redacted keys in v at any depth.
+func redactFields(v any, redact map[string]bool) any {
+	switch val := v.(type) {
+	case map[string]any:
+		for key, value := range val {
+			if redact[strings.ToLower(key)] {
+				val[key] = "[REDACTED]"
+			} else {
+				val[key] = redactFields(value, redact)
+			}
+		}
+	case []any:
+		for i, value := range val {
+			val[i] = redactFields(value, redact)
+		}
+	}
+	return v
+}
@@ -0,0 +1,28 @@
+package mux
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/obadmatar/base/log"
+)
+
+func TestBodyLoggerReturnsHandlerError(t *testing.T) {
+	level := log.GetLevel()
+	log.SetLevel(log.DebugLevel)
+	defer log.SetLevel(level)
+
+	handlerErr := errors.New("database unavailable")
+	h := BodyLogger(BodyLoggerOptions{})(HandlerFunc(func(ctx *Context) error {
+		return handlerErr
+	}))
+
+	ctx, rec := NewTestContext(http.MethodPost, "/users", nil)
+	if err := h.Handle(ctx); !errors.Is(err, handlerErr) {
+		t.Errorf("middleware returned %v, want the handler error", err)
+	}
+	if ctx.rsp.Written() {
+		t.Errorf("middleware answered the error with %d, want it left to the router", rec.Code)
+	}
+}
@@ -0,0 +1,41 @@
+package mux
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// CachePublic allows the response to be cached by clients and shared caches (e.g. CDNs) for maxAge.
+// It must be called before the response is written.
+func (ctx *Context) CachePublic(maxAge time.Duration) {
+	ctx.setCache("public", maxAge)
+}
+
+// CachePrivate allows the response to be cached by the client only, for maxAge.
+// It must be called before the response is written.
+func (ctx *Context) CachePrivate(maxAge time.Duration) {
+	ctx.setCache("private", maxAge)
+}
+
+// NoCache prevents the response from being stored by any cache.
+// It must be called before the response is written.
+func (ctx *Context) NoCache() {
+	header := ctx.rsp.Header()
+	header.Set("Cache-Control", "no-store, no-cache, must-revalidate, max-age=0")
+	header.Set("Expires", "0")
+	header.Set("Pragma", "no-cache")
+}
+
+// setCache sets the Cache-Control and Expires headers for the given visibility and max age.
+func (ctx *Context) setCache(visibility string, maxAge time.Duration) {
+	if maxAge < 0 {
+		maxAge = 0
+	}
+	seconds := int64(maxAge / time.Second)
+
+	header := ctx.rsp.Header()
+	header.Set("Cache-Control", visibility+", max-age="+strconv.FormatInt(seconds, 10))
+	header.Set("Expires", time.Now().Add(time.Duration(seconds)*time.Second).UTC().Format(http.TimeFormat))
+	header.Del("Pragma")
+}
@@ -0,0 +1,86 @@
+package mux
+
+import (
+	"net"
+	"net/netip"
+	"strings"
+)
+
+// ClientIP returns the IP address of the client. The X-Forwarded-For and X-Real-IP
+// headers are only honored when the request comes from one of the configured
+// TrustedProxies, so clients can't spoof their address by setting them.
+// X-Forwarded-For is read from right to left, skipping trusted proxies.
+func (ctx *Context) ClientIP() string {
+	if ctx.fromTrustedProxy() {
+		if ip := ctx.forwardedIP(); ip != "" {
+			return ip
+		}
+	}
+	return peerIP(ctx.req.RemoteAddr)
+}
+
+// fromTrustedProxy reports whether the immediate peer is a trusted proxy.
+func (ctx *Context) fromTrustedProxy() bool {
+	return isTrustedProxy(ctx.config.TrustedProxies, peerIP(ctx.req.RemoteAddr))
+}
+
+// forwardedIP returns the client IP set by trusted proxies, or empty if none.
+func (ctx *Context) forwardedIP() string {
+	// The rightmost untrusted address is the one seen by the first trusted proxy
+	if forwardedFor := ctx.Header("X-Forwarded-For"); forwardedFor != "" {
+		ips := strings.Split(forwardedFor, ",")
+		for i := len(ips) - 1; i >= 0; i-- {
+			ip := strings.TrimSpace(ips[i])
+			if ip == "" {
+				continue
+			}
+			if i == 0 || !isTrustedProxy(ctx.config.TrustedProxies, ip) {
+				return ip
+			}
+		}
+	}
+
+	return strings.TrimSpace(ctx.Header("X-Real-IP"))
+}
+
+// peerIP returns the IP part of a "host:port" address.
+func peerIP(remoteAddr string) string {
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		return remoteAddr
+	}
+	return host
+}
+
+// isTrustedProxy reports whether ip belongs to one of the trusted proxies.
+func isTrustedProxy(proxies []string, ip string) bool {
+	if len(proxies) == 0 {
+		return false
+	}
+
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+
+	for _, proxy := range proxies {
+		if prefix, ok := parseProxy(proxy); ok && prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseProxy parses a trusted proxy entry, either a CIDR or a single IP.
+func parseProxy(proxy string) (netip.Prefix, bool) {
+	proxy = strings.TrimSpace(proxy)
+	if prefix, err := netip.ParsePrefix(proxy); err == nil {
+		return prefix.Masked(), true
+	}
+	if addr, err := netip.ParseAddr(proxy); err == nil {
+		addr = addr.Unmap()
+		return netip.PrefixFrom(addr, addr.BitLen()), true
+	}
+	return netip.Prefix{}, false
+}
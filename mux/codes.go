@@ -0,0 +1,44 @@
+package mux
+
+// Error codes sent in the Error field of ErrorResponse. They can be overridden during
+// initialization to match an organization's vocabulary, e.g. mux.CodeDomainError = "domain.rule_violated".
+var (
+	// CodeDecodeError is sent when the request can't be decoded (400), e.g. malformed JSON.
+	CodeDecodeError = "DECODE_ERROR"
+
+	// CodeValidationError is sent when the decoded request fails validation (400).
+	CodeValidationError = "VALIDATION_ERROR"
+
+	// CodeDomainError is sent for DomainError (400) and NotFoundError (404).
+	CodeDomainError = "DOMAIN_ERROR"
+
+	// CodeMultipleErrors is sent for MultiError (400).
+	CodeMultipleErrors = "MULTIPLE_ERRORS"
+
+	// CodeInternalError is sent for unexpected errors (500).
+	CodeInternalError = "INTERNAL_ERROR"
+
+	// CodeTimeout is sent when the request deadline is exceeded (504).
+	CodeTimeout = "TIMEOUT"
+
+	// CodeCSRFInvalid is sent when a state-changing request has a missing or invalid CSRF token (403).
+	CodeCSRFInvalid = "CSRF_INVALID"
+
+	// CodeNotFound is sent for unmatched routes and missing files (404).
+	CodeNotFound = "NOT_FOUND"
+
+	// CodeMethodNotAllowed is sent when the route doesn't accept the request method (405).
+	CodeMethodNotAllowed = "METHOD_NOT_ALLOWED"
+
+	// CodePreconditionFailed is sent when an If-Match or If-Unmodified-Since precondition fails (412).
+	CodePreconditionFailed = "PRECONDITION_FAILED"
+
+	// CodeURITooLong is sent when the request URI exceeds Config.MaxURILength (414).
+	CodeURITooLong = "URI_TOO_LONG"
+
+	// CodeHeaderTooLarge is sent when the request headers exceed the limit (431).
+	CodeHeaderTooLarge = "HEADER_TOO_LARGE"
+
+	// CodeServiceUnavailable is sent when the server is busy or shutting down (503).
+	CodeServiceUnavailable = "SERVICE_UNAVAILABLE"
+)
@@ -0,0 +1,116 @@
+package mux
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// JSONCompact sends a JSON response like Send with the given status, with null values and
+// empty objects and arrays removed from objects at any depth, for bandwidth-sensitive clients.
+// Unlike omitempty tags, it applies regardless of the struct definitions. Array elements are
+// kept so their positions don't shift. Set Config.CompactJSON to compact every response.
+func (ctx *Context) JSONCompact(status int, body any) error {
+	compact := ctx.rsp.compact
+	ctx.rsp.compact = true
+	defer func() { ctx.rsp.compact = compact }()
+
+	return encode(ctx.rsp, status, body, nil)
+}
+
+// rewriteJSON rewrites the JSON document b, preserving the order of the fields.
+// If compact is set, null values and empty objects and arrays are removed from objects,
+// and if renameKey is not nil, object keys are replaced with renameKey(key).
+func rewriteJSON(b []byte, compact bool, renameKey func(string) string) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+
+	w := jsonRewriter{dec: dec, compact: compact, renameKey: renameKey}
+	w.buf.Grow(len(b))
+	if _, err := w.value(); err != nil {
+		return nil, err
+	}
+	return w.buf.Bytes(), nil
+}
+
+// jsonRewriter writes the tokens read from dec to buf, applying the rewrite options.
+type jsonRewriter struct {
+	dec       *json.Decoder
+	buf       bytes.Buffer
+	compact   bool
+	renameKey func(string) string
+}
+
+// value rewrites the next JSON value of the decoder, reporting whether
+// the value itself is null or an empty object or array.
+func (w *jsonRewriter) value() (empty bool, err error) {
+	tok, err := w.dec.Token()
+	if err != nil {
+		return false, err
+	}
+
+	switch t := tok.(type) {
+	case json.Delim:
+		if t == '[' {
+			w.buf.WriteByte('[')
+			n := 0
+			for ; w.dec.More(); n++ {
+				if n > 0 {
+					w.buf.WriteByte(',')
+				}
+				if _, err := w.value(); err != nil {
+					return false, err
+				}
+			}
+			w.buf.WriteByte(']')
+			_, err = w.dec.Token()
+			return n == 0, err
+		}
+
+		w.buf.WriteByte('{')
+		n := 0
+		for w.dec.More() {
+			tok, err := w.dec.Token()
+			if err != nil {
+				return false, err
+			}
+			key, _ := tok.(string)
+			if w.renameKey != nil {
+				key = w.renameKey(key)
+			}
+
+			// Write the field, then drop it if the value is empty
+			mark := w.buf.Len()
+			if n > 0 {
+				w.buf.WriteByte(',')
+			}
+			k, _ := json.Marshal(key)
+			w.buf.Write(k)
+			w.buf.WriteByte(':')
+
+			empty, err := w.value()
+			if err != nil {
+				return false, err
+			}
+			if empty && w.compact {
+				w.buf.Truncate(mark)
+			} else {
+				n++
+			}
+		}
+		w.buf.WriteByte('}')
+		_, err = w.dec.Token()
+		return n == 0, err
+
+	case nil:
+		w.buf.WriteString("null")
+		return true, nil
+
+	default:
+		v, err := json.Marshal(t)
+		if err != nil {
+			return false, err
+		}
+		w.buf.Write(v)
+		return false, nil
+	}
+}
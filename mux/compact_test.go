@@ -0,0 +1,73 @@
+package mux
+
+import (
+	"net/http"
+	"testing"
+)
+
+// discardResponseWriter is an http.ResponseWriter discarding the response body.
+type discardResponseWriter struct {
+	header http.Header
+}
+
+func (w *discardResponseWriter) Header() http.Header         { return w.header }
+func (w *discardResponseWriter) Write(b []byte) (int, error) { return len(b), nil }
+func (w *discardResponseWriter) WriteHeader(int)             {}
+
+type benchmarkAddress struct {
+	Street string  `json:"street"`
+	City   string  `json:"city"`
+	Zip    *string `json:"zip"`
+}
+
+type benchmarkUser struct {
+	ID        int64             `json:"id"`
+	FirstName string            `json:"firstName"`
+	LastName  string            `json:"lastName"`
+	Email     string            `json:"email"`
+	Nickname  *string           `json:"nickname"`
+	Roles     []string          `json:"roles"`
+	Tags      []string          `json:"tags"`
+	Address   benchmarkAddress  `json:"address"`
+	Metadata  map[string]string `json:"metadata"`
+}
+
+// benchmarkUsers returns a list of 100 users, with null values and empty arrays and objects.
+func benchmarkUsers() []benchmarkUser {
+	users := make([]benchmarkUser, 100)
+	for i := range users {
+		users[i] = benchmarkUser{
+			ID:        int64(i),
+			FirstName: "Ada",
+			LastName:  "Lovelace",
+			Email:     "ada@example.com",
+			Roles:     []string{"admin", "editor"},
+			Tags:      []string{},
+			Address:   benchmarkAddress{Street: "12 St James's Square", City: "London"},
+			Metadata:  map[string]string{},
+		}
+	}
+	return users
+}
+
+// benchmarkEncode measures encode writing body to a responseWriter configured by configure.
+func benchmarkEncode(b *testing.B, configure func(rw *responseWriter)) {
+	body := benchmarkUsers()
+	rw := &responseWriter{ResponseWriter: &discardResponseWriter{header: http.Header{}}}
+	configure(rw)
+
+	b.ReportAllocs()
+	for b.Loop() {
+		if err := encode(rw, http.StatusOK, body, nil); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkEncode(b *testing.B) {
+	benchmarkEncode(b, func(rw *responseWriter) {})
+}
+
+func BenchmarkEncodeCompact(b *testing.B) {
+	benchmarkEncode(b, func(rw *responseWriter) { rw.compact = true })
+}
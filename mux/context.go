@@ -2,11 +2,16 @@ package mux
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -21,8 +26,42 @@ type Context struct {
 	context.Context
 	requestID   string
 	currentUser string
+	config      *Config
+	router      *router
+	release     func()
+	trace       traceContext
 	req         *http.Request
-	rsp         http.ResponseWriter
+	rsp         *responseWriter
+
+	// allowUnknownFields disables the rejection of unknown JSON fields in Decode
+	allowUnknownFields bool
+
+	// warnings are the failed warn_ validations of the last Decode
+	warnings valid.Warnings
+
+	// features memoizes the feature flags evaluated for the request
+	features map[string]bool
+
+	// csrfToken is the CSRF token of the request, set by the CSRF middleware
+	csrfToken string
+
+	// timings are the spans recorded with StartSpan, guarded by timingsMu
+	timings   []Timing
+	timingsMu sync.Mutex
+}
+
+// Request returns the underlying *http.Request, as an escape hatch for integrations
+// that need the standard library types.
+func (ctx *Context) Request() *http.Request {
+	return ctx.req
+}
+
+// ResponseWriter returns the underlying http.ResponseWriter, as an escape hatch for
+// integrations that need the standard library types (e.g. SSE or streaming libraries).
+// Writing to it directly bypasses the framework's response helpers and error handling,
+// so a handler that writes directly should return nil.
+func (ctx *Context) ResponseWriter() http.ResponseWriter {
+	return ctx.rsp
 }
 
 // http.Request Methods
@@ -47,6 +86,140 @@ func (ctx *Context) Header(key string) string {
 	return ctx.req.Header.Get(key)
 }
 
+// UserAgent returns the User-Agent header of the request.
+func (ctx *Context) UserAgent() string {
+	return ctx.req.UserAgent()
+}
+
+// Referer returns the Referer header of the request.
+func (ctx *Context) Referer() string {
+	return ctx.req.Referer()
+}
+
+// Origin returns the Origin header of the request.
+func (ctx *Context) Origin() string {
+	return ctx.req.Header.Get("Origin")
+}
+
+// Host returns the host of the request, from the Host header or the URL.
+func (ctx *Context) Host() string {
+	return ctx.req.Host
+}
+
+// Locale returns the supported locale best matching the Accept-Language header, honoring
+// quality values (e.g. "fr-CH, fr;q=0.9, en;q=0.8, *;q=0.5"). A preferred "en-US" matches a
+// supported "en-US" first, then a supported locale of the same language ("en" or "en-GB").
+// It falls back to the first supported locale, or returns empty if none are supported.
+// The response is marked as varying by Accept-Language, for caches.
+func (ctx *Context) Locale(supported ...string) string {
+	if len(supported) == 0 {
+		return ""
+	}
+
+	ctx.AddVary("Accept-Language")
+
+	for _, tag := range acceptedLanguages(ctx.Header("Accept-Language")) {
+		if tag == "*" {
+			return supported[0]
+		}
+
+		// Exact match first, then a match on the language only
+		for _, locale := range supported {
+			if strings.EqualFold(locale, tag) {
+				return locale
+			}
+		}
+		for _, locale := range supported {
+			if strings.EqualFold(baseLanguage(locale), baseLanguage(tag)) {
+				return locale
+			}
+		}
+	}
+
+	return supported[0]
+}
+
+// acceptedLanguages returns the language tags of an Accept-Language header sorted by
+// decreasing quality, excluding those with a quality of 0.
+func acceptedLanguages(header string) []string {
+	type language struct {
+		tag     string
+		quality float64
+	}
+
+	var languages []language
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		tag = strings.TrimSpace(tag)
+		if tag == "" {
+			continue
+		}
+
+		quality := 1.0
+		if q, found := strings.CutPrefix(strings.TrimSpace(params), "q="); found {
+			v, err := strconv.ParseFloat(q, 64)
+			if err != nil {
+				continue
+			}
+			quality = v
+		}
+		if quality <= 0 {
+			continue
+		}
+
+		languages = append(languages, language{tag: tag, quality: quality})
+	}
+
+	sort.SliceStable(languages, func(i, j int) bool {
+		return languages[i].quality > languages[j].quality
+	})
+
+	tags := make([]string, len(languages))
+	for i, l := range languages {
+		tags[i] = l.tag
+	}
+	return tags
+}
+
+// baseLanguage returns the language part of a locale, e.g. "en" for "en-US".
+func baseLanguage(locale string) string {
+	base, _, _ := strings.Cut(strings.ReplaceAll(locale, "_", "-"), "-")
+	return base
+}
+
+// IsWebSocket reports whether the request asks for a WebSocket upgrade,
+// with the "Upgrade: websocket" and "Connection: upgrade" headers.
+func (ctx *Context) IsWebSocket() bool {
+	return headerContainsToken(ctx.req.Header, "Connection", "upgrade") &&
+		headerContainsToken(ctx.req.Header, "Upgrade", "websocket")
+}
+
+// IsSSE reports whether the request accepts a server-sent events stream ("Accept: text/event-stream"),
+// e.g. so middleware can skip buffering or compression for streaming responses.
+func (ctx *Context) IsSSE() bool {
+	for _, value := range ctx.req.Header.Values("Accept") {
+		for _, mediaType := range strings.Split(value, ",") {
+			mediaType, _, _ = strings.Cut(mediaType, ";")
+			if strings.EqualFold(strings.TrimSpace(mediaType), "text/event-stream") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// headerContainsToken reports whether the comma-separated values of the header contain token, ignoring case.
+func headerContainsToken(header http.Header, name, token string) bool {
+	for _, value := range header.Values(name) {
+		for _, t := range strings.Split(value, ",") {
+			if strings.EqualFold(strings.TrimSpace(t), token) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // Cookies returns all cookies sent with the request.
 func (ctx *Context) Cookies() []*http.Cookie {
 	return ctx.req.Cookies()
@@ -143,6 +316,65 @@ func (ctx *Context) QueryBoolOrError(name string) (bool, error) {
 	return boolVal, nil
 }
 
+// RequireQuery checks that all the named query parameters are present and non-empty.
+// It returns a BindingError listing every missing parameter at once.
+func (ctx *Context) RequireQuery(names ...string) error {
+	missing := make(map[string]string)
+	for _, name := range names {
+		if ctx.Query(name) == "" {
+			missing[name] = "is required"
+		}
+	}
+
+	if len(missing) > 0 {
+		return &BindingError{Message: "Missing Required Query Params", Errors: missing}
+	}
+
+	return nil
+}
+
+// QueryEnum returns the named query parameter if it is one of the allowed values, e.g.
+// ctx.QueryEnum("status", "active", "archived"). It returns an empty string if the parameter
+// is missing, for optional filters, and a BindingError listing the allowed values otherwise.
+func (ctx *Context) QueryEnum(name string, allowed ...string) (string, error) {
+	return ctx.queryEnum(name, false, allowed)
+}
+
+// QueryEnumFold is like QueryEnum, but matches the allowed values case-insensitively,
+// returning the allowed value as spelled in allowed, e.g. "asc" for "?order=ASC".
+func (ctx *Context) QueryEnumFold(name string, allowed ...string) (string, error) {
+	return ctx.queryEnum(name, true, allowed)
+}
+
+// QueryEnumOrDefault is like QueryEnumFold, but returns def if the parameter is missing,
+// e.g. ctx.QueryEnumOrDefault("order", "asc", "asc", "desc").
+func (ctx *Context) QueryEnumOrDefault(name, def string, allowed ...string) (string, error) {
+	value, err := ctx.queryEnum(name, true, allowed)
+	if value == "" && err == nil {
+		return def, nil
+	}
+	return value, err
+}
+
+// queryEnum returns the named query parameter matched against the allowed values.
+func (ctx *Context) queryEnum(name string, fold bool, allowed []string) (string, error) {
+	value := ctx.Query(name)
+	if value == "" {
+		return "", nil
+	}
+
+	for _, a := range allowed {
+		if value == a || (fold && strings.EqualFold(value, a)) {
+			return a, nil
+		}
+	}
+
+	return "", &BindingError{
+		Message: "Invalid Query Params",
+		Errors:  map[string]string{name: "must be one of: " + strings.Join(allowed, ", ")},
+	}
+}
+
 // QueryParams returns the map of query parameters.
 func (ctx *Context) QueryParams() map[string][]string {
 	return ctx.req.URL.Query()
@@ -153,37 +385,43 @@ func (ctx *Context) Body() io.ReadCloser {
 	return ctx.req.Body
 }
 
+// ReadAll reads the whole request body regardless of its content type.
+// The body is limited to the configured MaxBodyBytes, a BindingError is returned if it is exceeded.
+func (ctx *Context) ReadAll() ([]byte, error) {
+	return readBody(ctx.rsp, ctx.req, ctx.maxBodyBytes())
+}
+
+// SetBodyReadTimeout sets the deadline for reading the rest of the request body to d from now,
+// e.g. to let a slow large upload proceed while the server-wide ReadTimeout stays tight.
+// It overrides the deadline set by the server's ReadTimeout for the current request only,
+// and a d of zero or less removes the deadline. WriteTimeout still applies to the whole request.
+// It must be called before the body is read, and returns an error if the connection doesn't
+// support deadlines (e.g. in tests with an httptest.ResponseRecorder).
+func (ctx *Context) SetBodyReadTimeout(d time.Duration) error {
+	var deadline time.Time
+	if d > 0 {
+		deadline = time.Now().Add(d)
+	}
+	return http.NewResponseController(ctx.rsp).SetReadDeadline(deadline)
+}
+
+// RemoteAddr returns the client address as "ip:port". The X-Forwarded-For or X-Real-IP
+// header, with the X-Forwarded-Port header, are only honored when the request comes
+// from one of the configured TrustedProxies, otherwise the peer address is returned.
 func (ctx *Context) RemoteAddr() string {
-	// Check X-Forwarded-For header
-	if forwardedFor := ctx.Header("X-Forwarded-For"); forwardedFor != "" {
-		if ip := extractFirstIP(forwardedFor); ip != "" {
-			if port := ctx.Header("X-Forwarded-Port"); port != "" {
-				return fmt.Sprintf("%s:%s", ip, port)
-			}
-		}
+	if !ctx.fromTrustedProxy() {
+		return ctx.req.RemoteAddr
 	}
 
-	// Check X-Real-IP header
-	if realIP := ctx.req.Header.Get("X-Real-IP"); realIP != "" {
-		if port := ctx.Header("X-Forwarded-Port"); port != "" {
-			return fmt.Sprintf("%s:%s", realIP, port)
-		}
+	port := ctx.Header("X-Forwarded-Port")
+	if ip := ctx.forwardedIP(); ip != "" && port != "" {
+		return net.JoinHostPort(ip, port)
 	}
 
 	// Fallback to req.RemoteAddr
 	return ctx.req.RemoteAddr
 }
 
-func extractFirstIP(forwardedFor string) string {
-	for _, ip := range strings.Split(forwardedFor, ",") {
-		ip = strings.TrimSpace(ip)
-		if ip != "" {
-			return ip
-		}
-	}
-	return ""
-}
-
 // FormValue returns the first value for the named component of the form data.
 func (ctx *Context) FormValue(key string) string {
 	return ctx.req.FormValue(key)
@@ -206,11 +444,30 @@ func (ctx *Context) Write(data []byte) (int, error) {
 	return ctx.rsp.Write(data)
 }
 
+// Flush sends any buffered response data to the client immediately, e.g. for progress
+// reporting or streaming endpoints. It is a no-op if the underlying writer can't flush.
+func (ctx *Context) Flush() {
+	ctx.rsp.Flush()
+}
+
 // WriteHeader sets the HTTP status code for the response.
 func (ctx *Context) WriteHeader(statusCode int) {
 	ctx.rsp.WriteHeader(statusCode)
 }
 
+// SetStatus records the status code to use when the response is written without
+// an explicit status (e.g. Send or Write). It has no effect once the status is written.
+func (ctx *Context) SetStatus(code int) {
+	ctx.rsp.pending = code
+}
+
+// Status returns the status code written to the client, or the deferred status
+// set with SetStatus if the response has not been written yet.
+// Middleware can read it after calling the next handler, e.g. for access logs or metrics.
+func (ctx *Context) Status() int {
+	return ctx.rsp.Status()
+}
+
 // SetHeader sets a header field to a specific value.
 func (ctx *Context) SetHeader(key, value string) {
 	ctx.rsp.Header().Set(key, value)
@@ -223,8 +480,31 @@ func (ctx *Context) SetHeaders(headers map[string]string) {
 	}
 }
 
+// AddVary adds the given request headers to the Vary response header, so caches store a
+// response per value of the headers it depends on, e.g. "Accept-Language" for localized content.
+// Headers already listed are not duplicated, ignoring case, and nothing is added after "Vary: *".
+// It must be called before the response is written.
+func (ctx *Context) AddVary(headers ...string) {
+	header := ctx.rsp.Header()
+	for _, name := range headers {
+		if headerContainsToken(header, "Vary", name) || headerContainsToken(header, "Vary", "*") {
+			continue
+		}
+
+		if vary := strings.Join(header.Values("Vary"), ", "); vary != "" {
+			name = vary + ", " + name
+		}
+		header.Set("Vary", name)
+	}
+}
+
 // Custom Response methods
 
+// Send sends a JSON response with the status set by SetStatus, or 200 OK.
+func (ctx *Context) Send(body any) error {
+	return encode(ctx.rsp, 0, body, nil)
+}
+
 // OK sends a 200 OK response
 func (ctx *Context) OK(body any) error {
 	return encode(ctx.rsp, http.StatusOK, body, nil)
@@ -259,10 +539,27 @@ func (ctx *Context) badRequest(body any) {
 
 // InternalServerError sends a 500 Internal Server Error response.
 func (ctx *Context) internalServerError() {
+	ctx.internalServerErrorWith(nil)
+}
+
+// internalServerErrorWith sends a 500 Internal Server Error response,
+// including the error message and request ID when Config.Debug is set.
+func (ctx *Context) internalServerErrorWith(cause error) {
+	// A response already written (e.g. a failed write to a closed connection) can't be replaced
+	if ctx.rsp.Written() {
+		return
+	}
+
 	response := ErrorResponse{}
-	response.Error = "INTERNAL_ERROR"
+	response.Error = CodeInternalError
 	response.Message = "Something went wrong"
 	response.Status = http.StatusInternalServerError
+	if ctx.config.Debug {
+		if cause != nil {
+			response.Message = cause.Error()
+		}
+		response.RequestID = ctx.requestID
+	}
 	if err := ctx.InternalServerError(response); err != nil {
 		log.Error("mux: failed to send response", "error", err)
 	}
@@ -290,12 +587,101 @@ type Normalizer interface {
 // Decode parses the JSON-encoded request body into v and validates it.
 // It first decodes the body into v, checking for syntax errors, unknown fields,
 // and mismatched field types. Then it validates the struct using the validator package.
+// Requests with one of the configured RawContentTypes are rejected with 415 Unsupported
+// Media Type, their body being read with ReadAll.
 // Returns an error if decoding or validation fails.
 func (ctx *Context) Decode(v any) error {
+	return ctx.DecodeWith(v, nil)
+}
+
+// DecodeWith parses the JSON-encoded request body into v and validates it with the
+// given validator instead of the package default, for request-specific validations.
+// A nil validator uses the package default, like Decode.
+func (ctx *Context) DecodeWith(v any, validate *valid.Validator) error {
 	w, r := ctx.rsp, ctx.req
 
+	// Reject raw content types, read with ReadAll
+	if err := checkRawContentType(r, ctx.config.RawContentTypes); err != nil {
+		return err
+	}
+
 	// Decode JSON body into v
-	if err := decode(w, r, v); err != nil {
+	if err := decode(w, r, v, ctx.decodeOptions()); err != nil {
+		return err
+	}
+
+	// Normalize if applicable
+	if normalizer, ok := v.(Normalizer); ok {
+		normalizer.Normalize(ctx)
+	}
+
+	// Validate decoded struct, keeping warnings for the handler.
+	// Validation of a batch (slice) body stops between elements if the request is canceled.
+	validateStruct := valid.StructWithWarningsCtx
+	if validate != nil {
+		validateStruct = validate.StructWithWarningsCtx
+	}
+	warnings, err := validateStruct(ctx, v)
+	ctx.warnings = warnings
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DecodePartial parses the JSON-encoded request body into v like Decode, but validates only
+// the given fields, e.g. the fields of one step of a multi-step form sharing a struct.
+// Fields are Go struct field names, namespaced for nested fields, e.g. "Address.City".
+func (ctx *Context) DecodePartial(v any, fields ...string) error {
+	// Reject raw content types, read with ReadAll
+	if err := checkRawContentType(ctx.req, ctx.config.RawContentTypes); err != nil {
+		return err
+	}
+
+	if err := decode(ctx.rsp, ctx.req, v, ctx.decodeOptions()); err != nil {
+		return err
+	}
+
+	if normalizer, ok := v.(Normalizer); ok {
+		normalizer.Normalize(ctx)
+	}
+
+	return valid.StructPartial(v, fields...)
+}
+
+// ValidationWarnings returns the failed warn_ validations of the last Decode, or nil.
+// Handlers can include them in a successful response, e.g. as response metadata.
+func (ctx *Context) ValidationWarnings() valid.Warnings {
+	return ctx.warnings
+}
+
+// DecodeWeak parses the JSON-encoded request body into v like Decode, but coerces
+// string-encoded numbers and booleans (e.g. {"age": "30"}) into the target field types,
+// for loosely-typed clients. Fields are mapped using the `json` tag, and unknown fields
+// are ignored. Like Decode, embedded structs are flattened and time.Time fields are read
+// from RFC 3339 strings, but numbers decoded into interface{} fields are json.Number rather
+// than float64. The decoded struct is normalized and validated like Decode.
+func (ctx *Context) DecodeWeak(v any) error {
+	w, r := ctx.rsp, ctx.req
+
+	// Reject raw content types, read with ReadAll
+	if err := checkRawContentType(r, ctx.config.RawContentTypes); err != nil {
+		return err
+	}
+
+	// Decode JSON body into a generic map
+	// Numbers are kept as json.Number so large integers don't lose precision through float64
+	opts := ctx.decodeOptions()
+	opts.useNumber = true
+
+	var params map[string]any
+	if err := decode(w, r, &params, opts); err != nil {
+		return err
+	}
+
+	// Weakly decode the map into v
+	if err := decodeWeakJSON(params, v); err != nil {
 		return err
 	}
 
@@ -312,6 +698,14 @@ func (ctx *Context) Decode(v any) error {
 	return nil
 }
 
+// ValidateOnly decodes, normalizes and validates the JSON request body into v
+// without any further processing. It is meant for dry-run endpoints (e.g. live
+// form validation) that reuse a request struct but never execute domain logic.
+// Returns the same binding or validation errors as Decode.
+func (ctx *Context) ValidateOnly(v any) error {
+	return ctx.Decode(v)
+}
+
 // DecodeURL ...
 func (ctx *Context) DecodeURL(v any) error {
 	r := ctx.req
@@ -334,6 +728,177 @@ func (ctx *Context) DecodeURL(v any) error {
 	return nil
 }
 
+// BindAll binds a request carrying data in several sources into a single struct, then
+// normalizes and validates it once. Fields are filled from:
+//
+//   - the JSON body, using the `json` tag, when the request has a body
+//   - the query params, using the `query` tag
+//   - the path values, using the `path` tag, e.g. `path:"id"` for PUT /users/{id}
+//
+// When a field is set by several sources the later one wins, so path values take
+// precedence over query params, which take precedence over the body. Only fields with
+// a query or path tag are filled from those sources; tag them `json:"-"` to keep clients
+// from setting them through the body.
+func (ctx *Context) BindAll(v any) error {
+	w, r := ctx.rsp, ctx.req
+
+	// Decode JSON body into v, if any
+	if hasBody(r) {
+		if err := checkRawContentType(r, ctx.config.RawContentTypes); err != nil {
+			return err
+		}
+		if err := decode(w, r, v, ctx.decodeOptions()); err != nil {
+			return err
+		}
+	}
+
+	// Decode query params into v
+	if err := decodeMap(onlyTagged(queryParams(r), v, "query"), v, "query", "Query Params Decoding Failed"); err != nil {
+		return err
+	}
+
+	// Decode path values into v
+	if err := decodePath(r, v); err != nil {
+		return err
+	}
+
+	// Normalize if applicable
+	if normalizer, ok := v.(Normalizer); ok {
+		normalizer.Normalize(ctx)
+	}
+
+	// Validate decoded struct
+	if err := valid.Struct(v); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// BindHeader binds the request headers into v and validates it.
+// Fields are mapped using the `header` tag, e.g. `header:"X-Tenant-ID"`,
+// with case-insensitive header names. Missing required headers are reported
+// as validation errors.
+func (ctx *Context) BindHeader(v any) error {
+	// Decode headers into v
+	if err := decodeHeader(ctx.req, v); err != nil {
+		return err
+	}
+
+	// Normalize if applicable
+	if normalizer, ok := v.(Normalizer); ok {
+		normalizer.Normalize(ctx)
+	}
+
+	// Validate decoded struct
+	if err := valid.Struct(v); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// BindPath binds the request path values into v and validates it, for routes with several
+// path values, e.g. GET /orgs/{orgId}/users/{userId} with
+//
+//	struct {
+//		OrgID  int `path:"orgId" validate:"gt=0"`
+//		UserID int `path:"userId" validate:"gt=0"`
+//	}
+//
+// Values are read with http.Request.PathValue for the names of the `path` tags and converted
+// with weak typing; values that can't be converted are reported as field errors (400).
+func (ctx *Context) BindPath(v any) error {
+	// Decode path values into v
+	if err := decodePath(ctx.req, v); err != nil {
+		return err
+	}
+
+	// Normalize if applicable
+	if normalizer, ok := v.(Normalizer); ok {
+		normalizer.Normalize(ctx)
+	}
+
+	// Validate decoded struct
+	if err := valid.Struct(v); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DecodeForm parses a url-encoded or multipart form body into v and validates it.
+// Text fields are bound using the `form` tag, and uploaded files are bound into
+// *multipart.FileHeader or []*multipart.FileHeader fields with the same tag.
+// Multipart forms are parsed using the configured MaxMultipartMemory, MaxMultipartBytes
+// and MultipartTimeout.
+func (ctx *Context) DecodeForm(v any) error {
+	// Parse multipart forms within the configured size and time limits
+	if err := ctx.parseMultipartForm(); err != nil && !errors.Is(err, http.ErrNotMultipart) {
+		return err
+	}
+
+	// Decode form body into v
+	if err := decodeForm(ctx.req, v, ctx.maxMultipartMemory()); err != nil {
+		return err
+	}
+
+	// Normalize if applicable
+	if normalizer, ok := v.(Normalizer); ok {
+		normalizer.Normalize(ctx)
+	}
+
+	// Validate decoded struct
+	if err := valid.Struct(v); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// AllowUnknownFields makes Decode ignore JSON fields not defined in the target struct
+// for the current request, e.g. to tolerate newer clients sending extra fields.
+// The body must still contain a single JSON value.
+func (ctx *Context) AllowUnknownFields() {
+	ctx.allowUnknownFields = true
+}
+
+// decodeOptions returns the options used to decode the request body.
+func (ctx *Context) decodeOptions() decodeOptions {
+	return decodeOptions{
+		maxBytes:           ctx.maxBodyBytes(),
+		allowUnknownFields: ctx.allowUnknownFields,
+	}
+}
+
+// maxBodyBytes returns the configured request body limit or 1MB if unset.
+func (ctx *Context) maxBodyBytes() int64 {
+	if ctx.config.MaxBodyBytes > 0 {
+		return ctx.config.MaxBodyBytes
+	}
+	return 1 << 20
+}
+
+// maxMultipartMemory returns the configured multipart memory limit or 32MB if unset.
+func (ctx *Context) maxMultipartMemory() int64 {
+	if ctx.config.MaxMultipartMemory > 0 {
+		return ctx.config.MaxMultipartMemory
+	}
+	return 32 << 20
+}
+
+// DecodeQuery binds the query params into a new T, normalizes and validates it.
+// On failure it writes the standard error response and returns ok=false,
+// in which case the handler should return without writing a response.
+func DecodeQuery[T any](ctx *Context) (T, bool) {
+	var v T
+	if err := ctx.DecodeURL(&v); err != nil {
+		handleError(ctx, err)
+		return v, false
+	}
+	return v, true
+}
+
 // RequestID returns the unique request ID.
 func (ctx *Context) RequestID() string {
 	return ctx.requestID
@@ -344,12 +909,41 @@ func (ctx *Context) CurrentUser() string {
 	return ctx.currentUser
 }
 
+// SetCurrentUser associates the authenticated user with the request,
+// typically called by an authentication middleware.
+func (ctx *Context) SetCurrentUser(user string) {
+	ctx.currentUser = user
+}
+
 // newContext creates a new Context with a unique request ID.
-func newContext(w http.ResponseWriter, r *http.Request) *Context {
-	return &Context{
-		rsp:       w,
-		req:       r,
-		Context:   r.Context(),
-		requestID: uuid.NewString(),
+func newContext(w http.ResponseWriter, r *http.Request, config *Config) *Context {
+	if config == nil {
+		config = &Config{}
+	}
+
+	rsp := newResponseWriter(w)
+	rsp.contentType = config.DefaultContentType
+	rsp.compact = config.CompactJSON
+	rsp.renameKey = keyRenamer(config.JSONNamingStrategy)
+
+	ctx := &Context{
+		rsp:                rsp,
+		req:                r,
+		config:             config,
+		allowUnknownFields: config.AllowUnknownFields,
+		trace:              newTraceContext(r),
+		requestID:          newRequestID(config),
+	}
+
+	// Carry the correlation fields for loggers created with log.WithFields
+	ctx.Context = log.ContextWithFields(r.Context(), "request_id", ctx.requestID, "trace_id", ctx.trace.traceID)
+	return ctx
+}
+
+// newRequestID generates a request ID with the configured generator, or a random UUID.
+func newRequestID(config *Config) string {
+	if config.RequestIDGenerator != nil {
+		return config.RequestIDGenerator()
 	}
+	return uuid.NewString()
 }
@@ -0,0 +1,102 @@
+package mux
+
+import (
+	"reflect"
+	"strings"
+	"unicode"
+
+	"github.com/obadmatar/base/log"
+)
+
+// controllerMethods are the HTTP methods recognized as method name prefixes by RegisterController
+var controllerMethods = []string{"Get", "Post", "Put", "Patch", "Delete", "Head", "Options"}
+
+// RegisterController registers a route for each exported method of controller with the
+// signature func(*Context) error whose name starts with an HTTP method, relative to prefix:
+//
+//   - The prefix (Get, Post, Put, Patch, Delete, Head or Options) is the HTTP method,
+//     it must be followed by an uppercase letter or end the name.
+//   - The rest of the name is split into words, joined with dashes into a path segment,
+//     e.g. GetUserOrders → GET /user-orders.
+//   - "By" followed by a word is a path value named after that single lowercased word,
+//     e.g. GetUsersByID → GET /users/{id} and GetUsersByIDOrders → GET /users/{id}/orders.
+//   - A name made of the prefix alone maps to the prefix itself, e.g. Get → GET /.
+//
+// Acronyms are kept together, e.g. GetHTTPLogs → GET /http-logs. Other methods are ignored,
+// and methods named like routes but with another signature are skipped with a warning.
+// Pass a pointer to also register the methods with a pointer receiver.
+func RegisterController(router Router, prefix string, controller any) {
+	base := strings.TrimSuffix("/"+strings.Trim(prefix, "/"), "/")
+
+	v := reflect.ValueOf(controller)
+	t := v.Type()
+	for i := 0; i < t.NumMethod(); i++ {
+		method := t.Method(i)
+
+		httpMethod, rest, ok := splitControllerMethod(method.Name)
+		if !ok {
+			continue
+		}
+
+		fn, ok := v.Method(i).Interface().(func(*Context) error)
+		if !ok {
+			log.Warn("mux: Skipping controller method with an unsupported signature", "controller", t.String(), "method", method.Name)
+			continue
+		}
+
+		path := base + controllerPath(rest)
+		if path == "" {
+			path = "/"
+		}
+		router.Handle(httpMethod+" "+path, HandlerFunc(fn))
+	}
+}
+
+// splitControllerMethod splits a method name into its HTTP method and the rest of the name,
+// reporting whether it starts with a recognized HTTP method.
+func splitControllerMethod(name string) (httpMethod, rest string, ok bool) {
+	for _, m := range controllerMethods {
+		rest, found := strings.CutPrefix(name, m)
+		if !found {
+			continue
+		}
+		if rest != "" && !unicode.IsUpper([]rune(rest)[0]) {
+			continue
+		}
+		return strings.ToUpper(m), rest, true
+	}
+	return "", "", false
+}
+
+// controllerPath converts the rest of a controller method name into a path,
+// e.g. "UsersByIDOrders" to "/users/{id}/orders".
+func controllerPath(rest string) string {
+	if rest == "" {
+		return ""
+	}
+
+	var (
+		path    strings.Builder
+		segment []string
+	)
+	flush := func() {
+		if len(segment) > 0 {
+			path.WriteString("/" + strings.Join(segment, "-"))
+			segment = segment[:0]
+		}
+	}
+
+	words := strings.Split(snakeCase(rest), "_")
+	for i := 0; i < len(words); i++ {
+		if words[i] == "by" && i+1 < len(words) {
+			flush()
+			path.WriteString("/{" + words[i+1] + "}")
+			i++
+			continue
+		}
+		segment = append(segment, words[i])
+	}
+	flush()
+
+	return path.String()
+}
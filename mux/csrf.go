@@ -0,0 +1,181 @@
+package mux
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"mime"
+	"net/http"
+	"time"
+)
+
+// csrfTokenBytes is the number of random bytes of a CSRF token
+const csrfTokenBytes = 32
+
+// CSRFOptions configures the CSRF middleware.
+type CSRFOptions struct {
+	// CookieName is the name of the cookie holding the token (default: "csrf_token").
+	CookieName string
+
+	// HeaderName is the request header carrying the token (default: "X-CSRF-Token").
+	HeaderName string
+
+	// FormField is the form field carrying the token when the header is missing,
+	// for HTML forms posted without JavaScript. Url-encoded forms are limited to Config.MaxBodyBytes
+	// and multipart forms to the limits of Context.DecodeForm, oversized forms are rejected
+	// with 413 (default: none, header only).
+	FormField string
+
+	// CookiePath is the path of the cookie (default: "/").
+	CookiePath string
+
+	// CookieDomain is the domain of the cookie (default: the request host only).
+	CookieDomain string
+
+	// MaxAge is the lifetime of the cookie (default: 12 hours).
+	MaxAge time.Duration
+
+	// Secure restricts the cookie to HTTPS, it should be set in production.
+	Secure bool
+
+	// SameSite is the SameSite attribute of the cookie (default: http.SameSiteLaxMode).
+	SameSite http.SameSite
+}
+
+// CSRF returns a middleware protecting cookie-authenticated apps from cross-site request forgery
+// with the double-submit cookie pattern. A random token is set in a cookie readable by the
+// page's scripts, and state-changing requests must send it back in the header (or form field),
+// which a cross-site page can't do since it can't read the cookie. Requests with a missing or
+// mismatching token are rejected with a 403 ErrorResponse. Safe methods (GET, HEAD, OPTIONS
+// and TRACE) are exempt; handlers rendering forms get the token with Context.CSRFToken.
+func CSRF(opts CSRFOptions) MiddlewareFunc {
+	if opts.CookieName == "" {
+		opts.CookieName = "csrf_token"
+	}
+	if opts.HeaderName == "" {
+		opts.HeaderName = "X-CSRF-Token"
+	}
+	if opts.CookiePath == "" {
+		opts.CookiePath = "/"
+	}
+	if opts.MaxAge <= 0 {
+		opts.MaxAge = 12 * time.Hour
+	}
+	if opts.SameSite == 0 {
+		opts.SameSite = http.SameSiteLaxMode
+	}
+
+	return func(next Handler) Handler {
+		return HandlerFunc(func(ctx *Context) error {
+			// Reuse the token of the cookie, or issue a new one
+			var token string
+			if cookie, err := ctx.Cookie(opts.CookieName); err == nil && isValidCSRFToken(cookie.Value) {
+				token = cookie.Value
+			} else {
+				var err error
+				if token, err = newCSRFToken(); err != nil {
+					return err
+				}
+				ctx.SetCookie(&http.Cookie{
+					Name:     opts.CookieName,
+					Value:    token,
+					Path:     opts.CookiePath,
+					Domain:   opts.CookieDomain,
+					MaxAge:   int(opts.MaxAge / time.Second),
+					Secure:   opts.Secure,
+					HttpOnly: false, // read by the page's scripts to send it back in the header
+					SameSite: opts.SameSite,
+				})
+			}
+			ctx.csrfToken = token
+			ctx.AddVary("Cookie")
+
+			if isSafeMethod(ctx.Method()) {
+				return next.Handle(ctx)
+			}
+
+			// The token must be sent back, a new cookie issued above never matches
+			sent := ctx.Header(opts.HeaderName)
+			if sent == "" && opts.FormField != "" {
+				var err error
+				if sent, err = csrfFormValue(ctx, opts.FormField); err != nil {
+					return err
+				}
+			}
+			if sent == "" || subtle.ConstantTimeCompare([]byte(sent), []byte(token)) != 1 {
+				writeErrorResponse(ctx.rsp, http.StatusForbidden, CodeCSRFInvalid, "Missing or invalid CSRF token")
+				return nil
+			}
+
+			return next.Handle(ctx)
+		})
+	}
+}
+
+// csrfFormValue returns the value of the token field of url-encoded and multipart form bodies.
+// Url-encoded bodies are limited to Config.MaxBodyBytes, and multipart bodies are parsed with
+// the limits of Context.DecodeForm, which reuses the parsed form. Exceeding a limit returns
+// its BindingError (e.g. 413 or 408) rather than a missing token.
+func csrfFormValue(ctx *Context, field string) (string, error) {
+	r := ctx.req
+	if r.Body == nil {
+		return "", nil
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	switch mediaType {
+	case "multipart/form-data":
+		if err := ctx.parseMultipartForm(); err != nil {
+			return "", err
+		}
+	case "application/x-www-form-urlencoded":
+		if err := checkContentLength(r, ctx.maxBodyBytes()); err != nil {
+			return "", err
+		}
+		r.Body = http.MaxBytesReader(ctx.rsp, r.Body, ctx.maxBodyBytes())
+		if err := r.ParseForm(); err != nil {
+			var maxBytesError *http.MaxBytesError
+			if errors.As(err, &maxBytesError) {
+				err := newBindingError("body must not exceed %d bytes", maxBytesError.Limit)
+				err.status = http.StatusRequestEntityTooLarge
+				return "", err
+			}
+			return "", newBindingError("body contains a badly-formed form: %v", err)
+		}
+	default:
+		return "", nil
+	}
+
+	return r.PostForm.Get(field), nil
+}
+
+// CSRFToken returns the CSRF token of the request set by the CSRF middleware, e.g. to
+// embed it in a form field or a meta tag. It is empty if the middleware is not used.
+func (ctx *Context) CSRFToken() string {
+	return ctx.csrfToken
+}
+
+// newCSRFToken generates a random token with crypto/rand.
+func newCSRFToken() (string, error) {
+	b := make([]byte, csrfTokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// isValidCSRFToken reports whether the token has the format of the generated tokens.
+func isValidCSRFToken(token string) bool {
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	return err == nil && len(b) == csrfTokenBytes
+}
+
+// isSafeMethod reports whether requests with the given method are read-only.
+func isSafeMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace:
+		return true
+	}
+	return false
+}
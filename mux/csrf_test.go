@@ -0,0 +1,61 @@
+package mux
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestCSRFFormField(t *testing.T) {
+	token, err := newCSRFToken()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// multipartBody returns a multipart form with the token and a file of the given size
+	multipartBody := func(size int) (string, string) {
+		var body bytes.Buffer
+		mw := multipart.NewWriter(&body)
+		_ = mw.WriteField("csrf", token)
+		fw, _ := mw.CreateFormFile("file", "upload.bin")
+		_, _ = fw.Write(bytes.Repeat([]byte("a"), size))
+		_ = mw.Close()
+		return mw.FormDataContentType(), body.String()
+	}
+
+	smallType, smallBody := multipartBody(1 << 10)
+	largeType, largeBody := multipartBody(2 << 20)
+
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		want        int
+	}{
+		{"url-encoded", "application/x-www-form-urlencoded", url.Values{"csrf": {token}}.Encode(), http.StatusOK},
+		{"url-encoded too large", "application/x-www-form-urlencoded", "pad=" + strings.Repeat("a", 2<<20) + "&csrf=" + token, http.StatusRequestEntityTooLarge},
+		{"multipart", smallType, smallBody, http.StatusOK},
+		{"multipart larger than MaxBodyBytes", largeType, largeBody, http.StatusOK},
+		{"missing token", "application/x-www-form-urlencoded", "name=ada", http.StatusForbidden},
+	}
+
+	h := CSRF(CSRFOptions{FormField: "csrf"})(HandlerFunc(func(ctx *Context) error {
+		return ctx.OK(M{"ok": true})
+	}))
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/upload", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", tt.contentType)
+			req.AddCookie(&http.Cookie{Name: "csrf_token", Value: token})
+
+			if rec := TestHandler(h, req); rec.Code != tt.want {
+				t.Errorf("status = %d, want %d: %s", rec.Code, tt.want, rec.Body)
+			}
+		})
+	}
+}
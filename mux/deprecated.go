@@ -0,0 +1,39 @@
+package mux
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/obadmatar/base/log"
+)
+
+// Deprecated returns a middleware marking the routes it wraps as deprecated.
+// Responses carry a "Deprecation: true" header and, unless sunset is zero, a Sunset header
+// (RFC 8594) with the date the route stops working. Each hit is logged at warn level
+// with the route and client, to track lingering usage.
+func Deprecated(sunset time.Time) MiddlewareFunc {
+	var sunsetHeader string
+	if !sunset.IsZero() {
+		sunsetHeader = sunset.UTC().Format(http.TimeFormat)
+	}
+
+	return func(next Handler) Handler {
+		return HandlerFunc(func(ctx *Context) error {
+			ctx.SetHeader("Deprecation", "true")
+			if sunsetHeader != "" {
+				ctx.SetHeader("Sunset", sunsetHeader)
+			}
+
+			log.Warn("mux: Deprecated route called",
+				"route", ctx.req.Pattern,
+				"method", ctx.Method(),
+				"url", ctx.URI(),
+				"client_ip", ctx.ClientIP(),
+				"user_agent", ctx.UserAgent(),
+				"sunset", sunsetHeader,
+			)
+
+			return next.Handle(ctx)
+		})
+	}
+}
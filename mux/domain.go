@@ -4,33 +4,26 @@ import (
 	"net/http"
 
 	"github.com/obadmatar/base"
-	"github.com/obadmatar/base/log"
 )
 
 type DomainError = base.DomainError
 
 type NotFoundError = base.NotFoundError
 
-// sendDomainErrorResponse handles domain errors by sending a BadRequest response.
-func sendDomainErrorResponse(ctx *Context, d *DomainError) {
+// domainErrorResponse builds the BadRequest response of a domain error.
+func domainErrorResponse(d *DomainError) ErrorResponse {
 	response := ErrorResponse{}
-	response.Error = "DOMAIN_ERROR"
+	response.Error = CodeDomainError
 	response.Message = d.Message
 	response.Status = http.StatusBadRequest
-	if err := ctx.BadRequest(response); err != nil {
-		log.Error("mux: failed to respond", "error", err)
-		ctx.internalServerError()
-	}
+	return response
 }
 
-// sendNotFoundErrorResponse handles domain errors by sending a BadRequest response.
-func sendNotFoundErrorResponse(ctx *Context, d *NotFoundError) {
+// notFoundErrorResponse builds the NotFound response of a domain not found error.
+func notFoundErrorResponse(d *NotFoundError) ErrorResponse {
 	response := ErrorResponse{}
-	response.Error = "DOMAIN_ERROR"
+	response.Error = CodeDomainError
 	response.Message = d.Message
 	response.Status = http.StatusNotFound
-	if err := ctx.NotFound(response); err != nil {
-		log.Error("mux: failed to respond", "error", err)
-		ctx.internalServerError()
-	}
+	return response
 }
@@ -0,0 +1,104 @@
+package mux
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+)
+
+// Download sends data as a file attachment with the given filename and content type.
+// It is meant for content produced in memory (e.g. generated PDF or CSV reports).
+// The content type defaults to "application/octet-stream" if empty.
+// Range requests are answered with 206 Partial Content, and unsatisfiable ranges with
+// 416 Range Not Satisfiable, so downloads can be resumed.
+func (ctx *Context) Download(filename, contentType string, data []byte) error {
+	ctx.setAttachmentHeaders(filename, contentType)
+	http.ServeContent(ctx.rsp, ctx.req, "", time.Time{}, bytes.NewReader(data))
+	return nil
+}
+
+// DownloadStream sends the content read from r as a file attachment with the given filename
+// and content type, without buffering it in memory. It is meant for large generated files.
+// The content type defaults to "application/octet-stream" if empty.
+// If r is an io.ReadSeeker (e.g. an *os.File), range requests are supported like Download,
+// otherwise the full content is always sent with 200 OK.
+func (ctx *Context) DownloadStream(filename, contentType string, r io.Reader) error {
+	ctx.setAttachmentHeaders(filename, contentType)
+
+	if rs, ok := r.(io.ReadSeeker); ok {
+		http.ServeContent(ctx.rsp, ctx.req, "", time.Time{}, rs)
+		return nil
+	}
+
+	ctx.WriteHeader(http.StatusOK)
+	_, err := io.Copy(ctx.rsp, r)
+	return err
+}
+
+// setAttachmentHeaders sets the Content-Type and Content-Disposition headers of a download.
+func (ctx *Context) setAttachmentHeaders(filename, contentType string) {
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	ctx.SetHeader("Content-Type", contentType)
+	ctx.SetHeader("Content-Disposition", contentDisposition(filename))
+}
+
+// contentDisposition formats an attachment Content-Disposition header value.
+// Non-ASCII filenames are encoded as RFC 5987 filename* with an ASCII fallback.
+func contentDisposition(filename string) string {
+	filename = sanitizeFilename(filename)
+
+	fallback := asciiFilename(filename)
+	if fallback == filename {
+		return `attachment; filename="` + filename + `"`
+	}
+
+	return `attachment; filename="` + fallback + `"; filename*=UTF-8''` + encodeRFC5987(filename)
+}
+
+// sanitizeFilename strips directories, control characters, quotes and backslashes
+// from filename to prevent header injection.
+func sanitizeFilename(filename string) string {
+	filename = path.Base(strings.ReplaceAll(filename, `\`, "/"))
+
+	filename = strings.Map(func(r rune) rune {
+		if r < 0x20 || r == 0x7f || r == '"' {
+			return -1
+		}
+		return r
+	}, filename)
+
+	if filename == "" || filename == "." || filename == "/" {
+		return "download"
+	}
+	return filename
+}
+
+// asciiFilename replaces non-ASCII characters of filename with underscores.
+func asciiFilename(filename string) string {
+	return strings.Map(func(r rune) rune {
+		if r > 0x7e {
+			return '_'
+		}
+		return r
+	}, filename)
+}
+
+// encodeRFC5987 percent-encodes s, leaving only RFC 5987 attr-chars unescaped.
+func encodeRFC5987(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') || ('0' <= c && c <= '9') || strings.IndexByte("!#$&+-.^_`|~", c) >= 0 {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
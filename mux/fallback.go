@@ -0,0 +1,101 @@
+package mux
+
+import (
+	"net/http"
+	"strings"
+)
+
+// routeMethods are the methods probed to tell a method mismatch from an unknown path.
+var routeMethods = []string{
+	http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch,
+	http.MethodDelete, http.MethodOptions, http.MethodConnect, http.MethodTrace,
+}
+
+// NotFound sets the handler for requests matching no route, with middleware applied.
+// By default, a 404 ErrorResponse with the NOT_FOUND code is sent.
+func (r *router) NotFound(h Handler) {
+	r.notFound = h
+}
+
+// MethodNotAllowed sets the handler for requests whose path matches a route registered
+// for other methods, with middleware applied. The Allow header is set before it is called.
+// By default, a 405 ErrorResponse with the METHOD_NOT_ALLOWED code is sent.
+func (r *router) MethodNotAllowed(h Handler) {
+	r.methodNotAllowed = h
+}
+
+// routeHandler dispatches requests to the mux, or to the fallback handlers when no route
+// matches, instead of the plain-text responses of http.ServeMux.
+// OPTIONS requests for a registered path are answered with 204 No Content and an Allow
+// header listing the registered methods, unless an OPTIONS handler is registered.
+func (r *router) routeHandler() http.Handler {
+	notFound := r.notFound
+	if notFound == nil {
+		notFound = HandlerFunc(defaultNotFound)
+	}
+	methodNotAllowed := r.methodNotAllowed
+	if methodNotAllowed == nil {
+		methodNotAllowed = HandlerFunc(defaultMethodNotAllowed)
+	}
+
+	notFoundHandler := r.httpHandler(r.applyMiddlewares(notFound))
+	methodNotAllowedHandler := r.httpHandler(r.applyMiddlewares(methodNotAllowed))
+	optionsHandler := r.httpHandler(r.applyMiddlewares(HandlerFunc(defaultOptions)))
+
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if _, pattern := r.mux.Handler(req); pattern != "" {
+			r.mux.ServeHTTP(w, req)
+			return
+		}
+
+		if allowed := r.allowedMethods(req); len(allowed) > 0 {
+			// Answer OPTIONS requests unless an OPTIONS handler is registered for the path
+			if req.Method == http.MethodOptions {
+				w.Header().Set("Allow", strings.Join(append(allowed, http.MethodOptions), ", "))
+				optionsHandler.ServeHTTP(w, req)
+				return
+			}
+
+			w.Header().Set("Allow", strings.Join(allowed, ", "))
+			methodNotAllowedHandler.ServeHTTP(w, req)
+			return
+		}
+
+		notFoundHandler.ServeHTTP(w, req)
+	})
+}
+
+// allowedMethods returns the methods for which a route matches the request path.
+func (r *router) allowedMethods(req *http.Request) []string {
+	var allowed []string
+	for _, method := range routeMethods {
+		if method == req.Method {
+			continue
+		}
+
+		probe := req.Clone(req.Context())
+		probe.Method = method
+		if _, pattern := r.mux.Handler(probe); pattern != "" {
+			allowed = append(allowed, method)
+		}
+	}
+	return allowed
+}
+
+// defaultOptions sends a 204 response, the Allow header being set by routeHandler.
+func defaultOptions(ctx *Context) error {
+	ctx.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+// defaultNotFound sends a 404 ErrorResponse.
+func defaultNotFound(ctx *Context) error {
+	writeErrorResponse(ctx.rsp, http.StatusNotFound, CodeNotFound, "The requested resource was not found")
+	return nil
+}
+
+// defaultMethodNotAllowed sends a 405 ErrorResponse.
+func defaultMethodNotAllowed(ctx *Context) error {
+	writeErrorResponse(ctx.rsp, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "The request method is not allowed for this resource")
+	return nil
+}
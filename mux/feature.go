@@ -0,0 +1,34 @@
+package mux
+
+// FlagProvider evaluates feature flags for a request, e.g. by user or tenant,
+// keeping handlers decoupled from the flag system.
+type FlagProvider interface {
+	Enabled(ctx *Context, flag string) bool
+}
+
+// SetFlagProvider sets the provider evaluating feature flags for Context.Feature.
+// It should be called before ListenAndServe.
+func (r *router) SetFlagProvider(p FlagProvider) {
+	r.flags = p
+}
+
+// Feature reports whether the named feature flag is enabled for the request.
+// Flags are evaluated once per request with the router's FlagProvider,
+// and are disabled when no provider is set.
+func (ctx *Context) Feature(name string) bool {
+	if enabled, found := ctx.features[name]; found {
+		return enabled
+	}
+
+	if ctx.router == nil || ctx.router.flags == nil {
+		return false
+	}
+
+	if ctx.features == nil {
+		ctx.features = make(map[string]bool)
+	}
+
+	enabled := ctx.router.flags.Enabled(ctx, name)
+	ctx.features[name] = enabled
+	return enabled
+}
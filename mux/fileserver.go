@@ -0,0 +1,72 @@
+package mux
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// FileServer returns a handler serving the files of fsys for request paths starting with prefix,
+// e.g. r.Handle("GET /static/", mux.FileServer("/static/", os.DirFS("public"))).
+// Unlike http.FileServer, missing files are answered with a 404 ErrorResponse, filesystem
+// errors (e.g. permission denied) with a clean 500, and directories are only served through
+// their index.html, never listed. Range requests are answered with 206 Partial Content,
+// for resumable downloads and media seeking.
+func FileServer(prefix string, fsys fs.FS) HandlerFunc {
+	server := http.FileServerFS(fsys)
+
+	return func(ctx *Context) error {
+		name, found := strings.CutPrefix(ctx.req.URL.Path, prefix)
+		if !found {
+			return fileNotFound(ctx)
+		}
+
+		// Resolve the file name relative to the root of fsys
+		name = strings.TrimPrefix(path.Clean("/"+name), "/")
+		if name == "" {
+			name = "."
+		}
+
+		info, err := openStat(fsys, name)
+		if err == nil && info.IsDir() {
+			_, err = openStat(fsys, path.Join(name, "index.html"))
+		}
+		if errors.Is(err, fs.ErrNotExist) {
+			return fileNotFound(ctx)
+		}
+		if err != nil {
+			return fmt.Errorf("mux: failed to serve file %q: %w", name, err)
+		}
+
+		// Serve the file with the prefix stripped from the URL path
+		req := new(http.Request)
+		*req = *ctx.req
+		req.URL = new(url.URL)
+		*req.URL = *ctx.req.URL
+		req.URL.Path = "/" + strings.TrimPrefix(strings.TrimPrefix(ctx.req.URL.Path, prefix), "/")
+		req.URL.RawPath = ""
+
+		server.ServeHTTP(ctx.rsp, req)
+		return nil
+	}
+}
+
+// openStat opens the named file to check it can be read, and returns its info.
+func openStat(fsys fs.FS, name string) (fs.FileInfo, error) {
+	f, err := fsys.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return f.Stat()
+}
+
+// fileNotFound sends a 404 ErrorResponse for a missing file.
+func fileNotFound(ctx *Context) error {
+	writeErrorResponse(ctx.rsp, http.StatusNotFound, CodeNotFound, "The requested file was not found")
+	return nil
+}
@@ -4,12 +4,15 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"reflect"
 	"runtime"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"syscall"
 	"time"
 
@@ -20,6 +23,10 @@ import (
 )
 
 type Config struct {
+	// Host specifies the interface on which the HTTP server listens, e.g. "127.0.0.1" to only
+	// accept local connections (default: all interfaces).
+	Host string `env:"HTTP_HOST" default:""`
+
 	// Port specifies the port on which the HTTP server listens (default: "8080").
 	Port string `env:"HTTP_PORT" default:"8080"`
 
@@ -38,10 +45,74 @@ type Config struct {
 	// MaxHeaderBytes specifies the maximum size in bytes of request headers.
 	MaxHeaderBytes int `env:"HTTP_MAX_HEADER_BYTES"`
 
+	// MaxBodyBytes specifies the maximum size in bytes of request bodies read by
+	// Context.Decode and Context.ReadAll (default: 1MB).
+	MaxBodyBytes int64 `env:"HTTP_MAX_BODY_BYTES" default:"1048576"`
+
+	// AllowUnknownFields makes Context.Decode ignore JSON fields not defined in the
+	// target struct instead of rejecting the request (default: false).
+	// It can also be enabled per request with Context.AllowUnknownFields.
+	AllowUnknownFields bool `env:"HTTP_ALLOW_UNKNOWN_FIELDS" default:"false"`
+
+	// MaxURILength specifies the maximum length in bytes of the request URI (default: 8192).
+	// Longer URIs are rejected with 414 Request-URI Too Long.
+	MaxURILength int `env:"HTTP_MAX_URI_LENGTH" default:"8192"`
+
+	// MaxQueryParams specifies the maximum number of query parameters (default: 1000).
+	// Requests with more parameters are rejected with 400 Bad Request.
+	MaxQueryParams int `env:"HTTP_MAX_QUERY_PARAMS" default:"1000"`
+
+	// MaxMultipartMemory is the maximum number of bytes of a multipart form
+	// stored in memory; the remainder is stored on disk in temporary files (default: 32MB).
+	MaxMultipartMemory int64 `env:"HTTP_MAX_MULTIPART_MEMORY" default:"33554432"`
+
+	// MaxMultipartBytes is the maximum total size in bytes of a multipart form parsed by
+	// Context.DecodeForm; larger uploads are rejected with 413 (default: 100MB).
+	MaxMultipartBytes int64 `env:"HTTP_MAX_MULTIPART_BYTES" default:"104857600"`
+
+	// MultipartTimeout is the maximum duration in seconds for reading a multipart form in
+	// Context.DecodeForm, to protect upload endpoints from slow clients (default: 0, no timeout).
+	MultipartTimeout int `env:"HTTP_MULTIPART_TIMEOUT" default:"0"`
+
+	// RawContentTypes lists request media types whose body is read with Context.ReadAll.
+	// Context.Decode and the other decoding methods reject them with 415 Unsupported Media Type,
+	// so they can't be used to skip validation (default: none).
+	RawContentTypes []string `env:"HTTP_RAW_CONTENT_TYPES" default:""`
+
+	// DefaultContentType is the Content-Type of JSON responses, unless set on the
+	// response with Context.SetHeader (default: "application/json; charset=utf-8").
+	DefaultContentType string `env:"HTTP_DEFAULT_CONTENT_TYPE" default:"application/json; charset=utf-8"`
+
+	// CompactJSON removes null values and empty objects and arrays from every JSON response,
+	// like Context.JSONCompact. It re-parses every response body, costly on large responses (default: false).
+	CompactJSON bool `env:"HTTP_COMPACT_JSON" default:"false"`
+
+	// JSONNamingStrategy converts the keys of JSON responses without changing the struct tags:
+	// "none" (keys as tagged), "snake" (snake_case) or "camel" (camelCase). Map keys are converted too.
+	// It re-parses every response body, costly on large responses (default: "none").
+	JSONNamingStrategy string `env:"HTTP_JSON_NAMING_STRATEGY" default:"none"`
+
+	// MethodOverride enables the MethodOverride middleware, rewriting the method of
+	// POST requests carrying an X-HTTP-Method-Override header or a _method url-encoded form field (default: false).
+	MethodOverride bool `env:"HTTP_METHOD_OVERRIDE" default:"false"`
+
+	// Debug includes the actual error message and the request ID in 500 responses,
+	// to speed up debugging in development. Keep it off in production (default: false).
+	Debug bool `env:"HTTP_DEBUG" default:"false"`
+
 	// GracefulShutdown is the timeout in seconds to allow active connections
 	// to close before the server shuts down.
 	GracefulShutdown int `env:"GRACEFUL_SHUTDOWN_TIMEOUT" default:"10"`
 
+	// ShutdownDrainPeriod is the grace window in seconds between closing the listener
+	// and shutting down the server, during which the readiness handler fails and
+	// in-flight requests finish (default: 0, shut down right away).
+	ShutdownDrainPeriod int `env:"HTTP_SHUTDOWN_DRAIN_PERIOD" default:"0"`
+
+	// ShutdownDrainLogInterval is the interval in seconds at which the number of in-flight
+	// requests is logged during the drain period (default: 1).
+	ShutdownDrainLogInterval int `env:"HTTP_SHUTDOWN_DRAIN_LOG_INTERVAL" default:"1"`
+
 	// AllowedOrigins is a list of origins a cross-domain request can be executed from.
 	// If the special "*" value is present in the list, all origins will be allowed.
 	// An origin may contain a wildcard (*) to replace 0 or more characters
@@ -49,6 +120,20 @@ type Config struct {
 	// Only one wildcard can be used per origin.
 	// Default value is ["*"]
 	AllowedOrigins []string `env:"ALLOWED_ORIGINS" default:"*"`
+
+	// TrustedProxies lists the IPs or CIDRs of the reverse proxies allowed to set the
+	// X-Forwarded-For and X-Real-IP headers (e.g. "10.0.0.0/8"). Forwarded headers are
+	// ignored for requests from other peers (default: none, use the peer address).
+	TrustedProxies []string `env:"HTTP_TRUSTED_PROXIES" default:""`
+
+	// AllowOriginFunc is a custom function to validate the origin at request time
+	// (e.g. tenant-specific domains loaded from a database). When set, it takes
+	// precedence over AllowedOrigins.
+	AllowOriginFunc func(origin string) bool
+
+	// RequestIDGenerator generates the ID of each request, e.g. a ULID or nanoid
+	// for shorter, sortable IDs in logs. Defaults to a random UUID when unset.
+	RequestIDGenerator func() string
 }
 
 // Validate ensures that the Config struct has valid values.
@@ -64,6 +149,12 @@ func (c *Config) Validate() error {
 		c.Port = "8080"
 	}
 
+	// Host validation
+	if c.Host != "" && !isValidHost(c.Host) {
+		log.Warn("Invalid host, listening on all interfaces", "host", c.Host)
+		c.Host = ""
+	}
+
 	// Timeout validations
 	if c.ReadTimeout < 0 {
 		log.Warn("ReadTimeout is too low, defaulting to 0")
@@ -86,12 +177,75 @@ func (c *Config) Validate() error {
 		c.GracefulShutdown = 10
 	}
 
+	// Trusted proxies validation
+	for _, proxy := range c.TrustedProxies {
+		if _, ok := parseProxy(proxy); !ok {
+			log.Warn("Invalid TrustedProxies entry, ignoring it", "value", proxy)
+		}
+	}
+
+	// Drain period validation
+	if c.ShutdownDrainPeriod < 0 {
+		log.Warn("ShutdownDrainPeriod is too low, defaulting to 0")
+		c.ShutdownDrainPeriod = 0
+	}
+
+	if c.ShutdownDrainLogInterval <= 0 {
+		log.Warn("ShutdownDrainLogInterval is too low, defaulting to 1")
+		c.ShutdownDrainLogInterval = 1
+	}
+
 	// MaxHeaderBytes validation
 	if c.MaxHeaderBytes <= 0 {
 		log.Warn("MaxHeaderBytes is too low, defaulting to 1048576")
 		c.MaxHeaderBytes = 1048576 // 1MB
 	}
 
+	// MaxBodyBytes validation
+	if c.MaxBodyBytes <= 0 {
+		log.Warn("MaxBodyBytes is too low, defaulting to 1048576")
+		c.MaxBodyBytes = 1048576 // 1MB
+	}
+
+	// MaxURILength validation
+	if c.MaxURILength <= 0 {
+		log.Warn("MaxURILength is too low, defaulting to 8192")
+		c.MaxURILength = 8192
+	}
+
+	// MaxQueryParams validation
+	if c.MaxQueryParams <= 0 {
+		log.Warn("MaxQueryParams is too low, defaulting to 1000")
+		c.MaxQueryParams = 1000
+	}
+
+	// MaxMultipartMemory validation
+	if c.MaxMultipartMemory <= 0 {
+		log.Warn("MaxMultipartMemory is too low, defaulting to 33554432")
+		c.MaxMultipartMemory = 32 << 20 // 32MB
+	}
+
+	// Multipart limits validation
+	if c.MaxMultipartBytes <= 0 {
+		log.Warn("MaxMultipartBytes is too low, defaulting to 104857600")
+		c.MaxMultipartBytes = 100 << 20 // 100MB
+	}
+
+	if c.MultipartTimeout < 0 {
+		log.Warn("MultipartTimeout is too low, defaulting to 0")
+		c.MultipartTimeout = 0
+	}
+
+	// JSON naming validation
+	if c.JSONNamingStrategy == "" {
+		c.JSONNamingStrategy = JSONNamingNone
+	}
+
+	if !isValidJSONNaming(c.JSONNamingStrategy) {
+		log.Warn("Invalid JSONNamingStrategy, using default value none", "value", c.JSONNamingStrategy)
+		c.JSONNamingStrategy = JSONNamingNone
+	}
+
 	// Final validation check for non-negative timeout values
 	if c.ReadTimeout < 0 {
 		log.Error("Invalid ReadTimeout, must be non-negative", "value", c.ReadTimeout)
@@ -121,6 +275,26 @@ func (c *Config) Validate() error {
 	return nil
 }
 
+// DefaultConfig returns a valid Config holding the documented default of every field,
+// e.g. port 8080, 1MB headers and bodies, a 10s graceful shutdown and CORS allowing any origin.
+// It is the reference for the expected values, and passes Validate without warnings.
+func DefaultConfig() *Config {
+	return &Config{
+		Port:                     "8080",
+		MaxHeaderBytes:           1 << 20, // 1MB
+		MaxBodyBytes:             1 << 20, // 1MB
+		MaxURILength:             8192,
+		MaxQueryParams:           1000,
+		MaxMultipartMemory:       32 << 20,  // 32MB
+		MaxMultipartBytes:        100 << 20, // 100MB
+		DefaultContentType:       defaultContentType,
+		JSONNamingStrategy:       JSONNamingNone,
+		GracefulShutdown:         10,
+		ShutdownDrainLogInterval: 1,
+		AllowedOrigins:           []string{"*"},
+	}
+}
+
 // isValidPort checks if the given string is a valid port number
 func isValidPort(port string) bool {
 	// Check if the port string is a valid integer and within the range of 1-65535
@@ -131,6 +305,28 @@ func isValidPort(port string) bool {
 	return err == nil && portInt > 0 && portInt <= 65535
 }
 
+// isValidHost checks if the given string is an IP address (optionally in brackets) or a host name
+func isValidHost(host string) bool {
+	host = strings.TrimSuffix(strings.TrimPrefix(host, "["), "]")
+	if net.ParseIP(host) != nil {
+		return true
+	}
+	if len(host) > 253 {
+		return false
+	}
+	for _, label := range strings.Split(host, ".") {
+		if label == "" || len(label) > 63 || strings.HasPrefix(label, "-") || strings.HasSuffix(label, "-") {
+			return false
+		}
+		for _, c := range label {
+			if !('a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9' || c == '-') {
+				return false
+			}
+		}
+	}
+	return true
+}
+
 // Handler defines an interface for handling HTTP requests.
 // Custom handlers must implement this interface.
 // Handle receives a Context and returns an error if the processing fails.
@@ -151,6 +347,22 @@ func (f HandlerFunc) Handle(ctx *Context) error {
 	return f(ctx)
 }
 
+// Bind adapts a typed handler into a Handler, decoding and validating the request body
+// into a T with Context.Decode before calling fn, e.g.
+//
+//	r.Handle("POST /users", mux.Bind(func(ctx *mux.Context, req CreateUserRequest) error { ... }))
+//
+// Binding and validation errors are returned without calling fn, resulting in the standard error response.
+func Bind[T any](fn func(ctx *Context, req T) error) Handler {
+	return HandlerFunc(func(ctx *Context) error {
+		var req T
+		if err := ctx.Decode(&req); err != nil {
+			return err
+		}
+		return fn(ctx, req)
+	})
+}
+
 // Router provides basic request routing and middleware support.
 // It simplifies handler management compared to the default http.ServeMux.
 type Router interface {
@@ -158,38 +370,102 @@ type Router interface {
 	// It maps the given pattern to the given Handler.
 	Handle(pattern string, h Handler)
 
+	// HandleDoc registers a new route like Handle, along with its documentation.
+	HandleDoc(pattern string, h Handler, doc RouteDoc)
+
+	// Routes returns the registered routes with their documentation, sorted by pattern.
+	Routes() []Route
+
+	// NotFound sets the handler for requests matching no route.
+	NotFound(h Handler)
+
+	// MethodNotAllowed sets the handler for requests matching a route with another method.
+	MethodNotAllowed(h Handler)
+
 	// Use adds one or more middleware functions to the router.
 	// Middleware is applied to all routes.
 	Use(middleware ...MiddlewareFunc)
 
+	// UseOuter adds one or more net/http middleware applied outside CORS,
+	// so they also run for CORS preflight requests.
+	UseOuter(middleware ...func(http.Handler) http.Handler)
+
 	// ListenAndServe starts the HTTP server on the configured address.
 	ListenAndServe() error
+
+	// LogStartupConfig logs a summary of the effective configuration.
+	LogStartupConfig()
+
+	// SetFlagProvider sets the provider evaluating feature flags for Context.Feature.
+	SetFlagProvider(p FlagProvider)
+
+	// ReadinessHandler returns a handler answering readiness probes, failing once the server is shutting down.
+	ReadinessHandler() Handler
+
+	// AddResponseTransformer registers a transformer applied to JSON response bodies before marshaling.
+	AddResponseTransformer(fn ResponseTransformer)
 }
 
 type router struct {
-	config   *Config
-	mux      *http.ServeMux
-	mwares   []MiddlewareFunc
-	handlers map[string]Handler
+	config    *Config
+	mux       *http.ServeMux
+	mwares    []MiddlewareFunc
+	outer     []func(http.Handler) http.Handler
+	handlers  map[string]route
+	keepAlive keepAliveRegistry
+
+	// notFound and methodNotAllowed handle unmatched requests, nil for the defaults
+	notFound         Handler
+	methodNotAllowed Handler
+
+	// flags evaluates feature flags for Context.Feature, nil if unset
+	flags FlagProvider
+
+	// transformers reshape JSON response bodies before marshaling, in registration order
+	transformers []ResponseTransformer
+
+	// draining is set once the server starts shutting down, inFlight counts active requests
+	draining atomic.Bool
+	inFlight atomic.Int64
 }
 
-// NewRouter creates a new Router with the provided logger.
+// NewRouter creates a new Router with the provided config.
+// The config is validated right away, invalid values being corrected to their defaults
+// and logged, so misconfigurations are reported at construction rather than on ListenAndServe.
 func NewRouter(config *Config) Router {
+	if config == nil {
+		config = &Config{}
+	}
+
+	if err := config.Validate(); err != nil {
+		log.Error("mux: Invalid router config", "error", err)
+	}
+
 	return &router{
 		config:   config,
 		mux:      http.NewServeMux(),
 		mwares:   make([]MiddlewareFunc, 0),
-		handlers: make(map[string]Handler),
+		handlers: make(map[string]route),
 	}
 }
 
 // Handle registers a new handler for the given pattern.
 // Logs a warning if a handler for the pattern already exists.
 func (r *router) Handle(pattern string, h Handler) {
+	r.handle(pattern, h, nil)
+}
+
+// HandleDoc registers a new handler for the given pattern along with its documentation.
+func (r *router) HandleDoc(pattern string, h Handler, doc RouteDoc) {
+	r.handle(pattern, h, &doc)
+}
+
+// handle stores the handler and its optional documentation for the given pattern.
+func (r *router) handle(pattern string, h Handler, doc *RouteDoc) {
 	if _, found := r.handlers[pattern]; found {
 		log.Fatal("mux: Handler already exists", "pattern", pattern)
 	}
-	r.handlers[pattern] = h
+	r.handlers[pattern] = route{handler: h, doc: doc}
 }
 
 // Use adds middleware functions to the router.
@@ -197,6 +473,15 @@ func (r *router) Use(middleware ...MiddlewareFunc) {
 	r.mwares = append(r.mwares, middleware...)
 }
 
+// UseOuter adds net/http middleware applied around the whole server handler, outside CORS,
+// e.g. for request-ID assignment or TLS checks that must also run for preflight requests.
+// The first middleware added is the outermost. A request goes through, in order:
+//
+//	UseOuter middleware → CORS → request limits → method override → routing → Use middleware → handler
+func (r *router) UseOuter(middleware ...func(http.Handler) http.Handler) {
+	r.outer = append(r.outer, middleware...)
+}
+
 // applyMiddlewares wraps a handler with all registered middleware.
 func (r *router) applyMiddlewares(h Handler) Handler {
 	for i := len(r.mwares) - 1; i >= 0; i-- {
@@ -208,7 +493,21 @@ func (r *router) applyMiddlewares(h Handler) Handler {
 // httpHandler adapts a custom Handler to a http.Handler.
 func (r *router) httpHandler(h Handler) http.Handler {
 	return http.HandlerFunc(func(rsp http.ResponseWriter, req *http.Request) {
-		r.handleRequest(newContext(rsp, req), h)
+		r.inFlight.Add(1)
+		defer r.inFlight.Add(-1)
+
+		ctx := newContext(rsp, req, r.config)
+		ctx.router = r
+		if len(r.transformers) > 0 {
+			ctx.rsp.transform = func(body any) any { return r.transformBody(ctx, body) }
+		}
+
+		r.handleRequest(ctx, h)
+
+		// Unregister long-lived requests
+		if ctx.release != nil {
+			ctx.release()
+		}
 	})
 }
 
@@ -220,6 +519,9 @@ type ErrorResponse struct {
 	Error   string            `json:"error"`   // "VALIDATION_ERROR", "DECODE_ERROR"..etc
 	Message string            `json:"message"` // A user-friendly message describing the error
 	Errors  map[string]string `json:"errors"`  // Field-specific friendly error message
+
+	// RequestID identifies the failed request, only set for 500 responses in Config.Debug mode
+	RequestID string `json:"request_id,omitempty"`
 }
 
 // handleRequest centralizes request processing and error handling.
@@ -231,66 +533,219 @@ func (r *router) handleRequest(ctx *Context, h Handler) {
 
 			// Log the error and stack trace
 			err := fmt.Sprintf("panic: %v\n%s", rec, string(buf))
-			log.Error("mux: Panic in request handler", "method", ctx.Method(), "url", ctx.URI(), "error", err)
+			log.Error("mux: Panic in request handler", "method", ctx.Method(), "url", ctx.URI(), "trace_id", ctx.TraceID(), "error", err)
 
 			// respond
-			ctx.internalServerError()
+			ctx.internalServerErrorWith(fmt.Errorf("panic: %v", rec))
 		}
 	}()
 
-	// handles specific error types by sending appropriate responses.
-	// If binding, validation or domain error, it responds accordingly
-	// otherwise, it returns a 500 error.
 	if err := h.Handle(ctx); err != nil {
-		log.Error("mux: Error in handler", "method", ctx.Method(), "url", ctx.URI(), "error", err)
-		// Handle Binding Errors
-		var b *BindingError
-		if errors.As(err, &b) {
-			sendDecodeErrorResponse(ctx, b)
-			return
-		}
+		respondError(ctx, err)
+	}
+}
 
-		// Handle Validation Errors
-		var v valid.Errors
-		if errors.As(err, &v) {
-			sendValidationErrorResponse(ctx, v)
-			return
+// respondError sends the response for the error returned by a handler.
+func respondError(ctx *Context, err error) {
+	// Send result types with their inferred status, even if wrapped by a middleware
+	var res Responder
+	if errors.As(err, &res) {
+		sendResult(ctx, res)
+		return
+	}
+
+	// The client disconnected, there is no one to respond to
+	if isClientDisconnect(err) || (errors.Is(err, context.Canceled) && ctx.Err() != nil) {
+		log.Debug("mux: Client disconnected", "method", ctx.Method(), "url", ctx.URI(), "trace_id", ctx.TraceID(), "error", err)
+		return
+	}
+
+	log.Error("mux: Error in handler", "method", ctx.Method(), "url", ctx.URI(), "trace_id", ctx.TraceID(), "error", err)
+
+	// The response was already sent (e.g. a failed stream), it can't be replaced
+	if ctx.rsp.Written() {
+		return
+	}
+
+	handleError(ctx, err)
+}
+
+// isClientDisconnect reports whether err is a write to a connection closed by the client
+// (e.g. a broken pipe while streaming) or timed out by http.TimeoutHandler.
+func isClientDisconnect(err error) bool {
+	return errors.Is(err, syscall.EPIPE) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, net.ErrClosed) ||
+		errors.Is(err, http.ErrHandlerTimeout)
+}
+
+// handleError handles specific error types by sending appropriate responses.
+// If binding, validation or domain error, it responds accordingly
+// otherwise, it returns a 500 error.
+func handleError(ctx *Context, err error) {
+	// Report Binding Errors
+	var b *BindingError
+	if errors.As(err, &b) {
+		reportBindingError(b)
+	}
+
+	response, internal := buildErrorResponse(err)
+	if internal != nil {
+		// Return a generic 500 Internal Server Error for other errors
+		ctx.internalServerErrorWith(internal)
+
+		// Un-handled error, encoding errors are logged by encode
+		var e *EncodeError
+		if !errors.As(err, &e) {
+			log.Error("mux: Error handling request", "url", ctx.URI(), "trace_id", ctx.TraceID(), "error", err)
 		}
+		return
+	}
 
-		// Handle Domain Not Found Errors
-		var n *NotFoundError
-		if errors.As(err, &n) {
-			sendNotFoundErrorResponse(ctx, n)
+	if err := encode(ctx.rsp, response.Status, response, nil); err != nil {
+		if isClientDisconnect(err) {
+			log.Debug("mux: Client disconnected before the error response", "error", err)
 			return
 		}
+		log.Error("mux: failed to respond", "error", err)
+		ctx.internalServerError()
+	}
+}
 
-		// Handle Domain Errors
-		var d *DomainError
-		if errors.As(err, &d) {
-			sendDomainErrorResponse(ctx, d)
-			return
+// BuildErrorResponse returns the ErrorResponse and status the framework sends for err when it's
+// returned by a handler, for handlers answering errors themselves, e.g.
+//
+//	response, status := mux.BuildErrorResponse(err)
+//	response.Message = "Import failed: " + response.Message
+//	ctx.SetStatus(status)
+//	return ctx.Send(response)
+//
+// Binding and validation errors map to 400 with their field errors, not found errors to 404,
+// domain errors to 400, context.DeadlineExceeded to 504, and other errors to a generic 500.
+func BuildErrorResponse(err error) (ErrorResponse, int) {
+	response, internal := buildErrorResponse(err)
+	if internal != nil {
+		response = ErrorResponse{}
+		response.Error = CodeInternalError
+		response.Message = "Something went wrong"
+		response.Status = http.StatusInternalServerError
+	}
+	return response, response.Status
+}
+
+// pendingResponse returns the status and body the router sends for err, a non-nil error returned
+// by a handler, for middleware recording the response while returning err unchanged.
+func pendingResponse(err error) (int, any) {
+	var res Responder
+	if errors.As(err, &res) {
+		return res.StatusCode(), res.ResponseBody()
+	}
+
+	response, status := BuildErrorResponse(err)
+	return status, response
+}
+
+// buildErrorResponse classifies err into the ErrorResponse to send, or returns
+// the error to answer with a 500 Internal Server Error if it isn't a known type.
+func buildErrorResponse(err error) (ErrorResponse, error) {
+	// Handle Aggregated Errors
+	var m *MultiError
+	if errors.As(err, &m) {
+		return multiErrorResponse(m)
+	}
+
+	// Handle Binding Errors
+	var b *BindingError
+	if errors.As(err, &b) {
+		return decodeErrorResponse(b), nil
+	}
+
+	// Handle Validation Errors
+	var v valid.Errors
+	if errors.As(err, &v) {
+		return validationErrorResponse(v), nil
+	}
+
+	// Handle Oversized Headers
+	var hl *HeaderTooLargeError
+	if errors.As(err, &hl) {
+		return headerTooLargeErrorResponse(hl), nil
+	}
+
+	// Handle Domain Not Found Errors
+	var n *NotFoundError
+	if errors.As(err, &n) {
+		return notFoundErrorResponse(n), nil
+	}
+
+	// Handle Domain Errors
+	var d *DomainError
+	if errors.As(err, &d) {
+		return domainErrorResponse(d), nil
+	}
+
+	// Handle Timeouts, e.g. a downstream call exceeding the request deadline
+	if errors.Is(err, context.DeadlineExceeded) {
+		response := ErrorResponse{}
+		response.Error = CodeTimeout
+		response.Message = "The request timed out"
+		response.Status = http.StatusGatewayTimeout
+		return response, nil
+	}
+
+	// Response encoding errors and other errors are internal errors
+	return ErrorResponse{}, err
+}
+
+// LogStartupConfig logs the effective configuration as structured fields in a single line.
+// Values of fields tagged with `sensitive:"true"` are redacted, and function fields
+// are only reported as set or unset.
+func (r *router) LogStartupConfig() {
+	v := reflect.ValueOf(r.config).Elem()
+	t := v.Type()
+
+	args := make([]any, 0, t.NumField()*2)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		// Use the env variable name if available
+		name := strings.Split(field.Tag.Get("env"), ",")[0]
+		if name == "" || name == "-" {
+			name = field.Name
 		}
 
-		// Return a generic 500 Internal Server Error for other errors
-		ctx.internalServerError()
+		var value any
+		switch {
+		case field.Tag.Get("sensitive") == "true":
+			value = "[REDACTED]"
+		case field.Type.Kind() == reflect.Func:
+			value = !v.Field(i).IsNil()
+		default:
+			value = v.Field(i).Interface()
+		}
 
-		// Un-handled error
-		log.Error("mux: Error handling request", "url", ctx.URI(), "error", err)
+		args = append(args, name, value)
 	}
+
+	log.Info("mux: Startup configuration", args...)
 }
 
 // ListenAndServe starts the HTTP server with the registered routes and handlers.
 // It listens on the configured address and blocks until the server shuts down or encounters an error.
 // Any server errors during shutdown are logged.
 func (r *router) ListenAndServe() error {
+	// Log the effective configuration
+	r.LogStartupConfig()
+
 	// Register routes with middleware applied.
-	for pattern, handler := range r.handlers {
+	for pattern, route := range r.handlers {
 		// Apply any defined middlewares to the handlers.
-		r.mux.Handle(pattern, r.httpHandler(r.applyMiddlewares(handler)))
+		r.mux.Handle(pattern, r.httpHandler(r.applyMiddlewares(route.handler)))
 	}
 
-	// Needs to be updated to read host from config variables.
-	addr := ":" + r.config.Port
+	// An empty host listens on all interfaces
+	host := strings.TrimSuffix(strings.TrimPrefix(r.config.Host, "["), "]")
+	addr := net.JoinHostPort(host, strings.TrimPrefix(r.config.Port, ":"))
 
 	// CORS configurations
 	opts := cors.Options{
@@ -299,26 +754,50 @@ func (r *router) ListenAndServe() error {
 		AllowedMethods: []string{"GET", "POST", "PUT", "PATCH", "DELETE"},
 	}
 
+	// Dynamic origin validation overrides the static list
+	if r.config.AllowOriginFunc != nil {
+		opts.AllowOriginFunc = r.config.AllowOriginFunc
+	}
+
+	// Apply request limits and method override before routing
+	var handler http.Handler = r.routeHandler()
+	if r.config.MethodOverride {
+		handler = methodOverride(r.config.MaxBodyBytes)(handler)
+	}
+	handler = r.limitRequest(handler)
+
 	// Apply CORS
-	muxWithCORS := cors.New(opts).Handler(r.mux)
+	handler = cors.New(opts).Handler(handler)
+
+	// Apply outer middleware, the first one added being the outermost
+	for i := len(r.outer) - 1; i >= 0; i-- {
+		handler = r.outer[i](handler)
+	}
 
 	// Configure the HTTP server with the given address and router.
 	server := &http.Server{
 		Addr:           addr,
-		Handler:        muxWithCORS,
+		Handler:        handler,
 		MaxHeaderBytes: r.config.MaxHeaderBytes,
 		IdleTimeout:    time.Duration(r.config.IdleTimeout) * time.Second,
 		ReadTimeout:    time.Duration(r.config.ReadTimeout) * time.Second,
 		WriteTimeout:   time.Duration(r.config.WriteTimeout) * time.Second,
 	}
 
+	// Listen on the address, the listener is closed first on shutdown.
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		log.Error("mux: Server error occurred", "error", err)
+		return err
+	}
+
 	// Channel to capture server errors.
 	done := make(chan error, 1)
 
 	go func() {
 		log.Info("mux: Starting HTTP server", "address", addr)
-		// Listen for incoming HTTP requests; report any startup errors.
-		done <- server.ListenAndServe()
+		// Serve incoming HTTP requests; report any runtime errors.
+		done <- server.Serve(ln)
 	}()
 
 	// Capture OS interrupt signals (SIGINT, SIGTERM).
@@ -336,6 +815,14 @@ func (r *router) ListenAndServe() error {
 		// Handle graceful shutdown on receiving an interrupt signal.
 		log.Info("mux: Shutdown signal received, shutting down server...")
 
+		// Notify long-lived requests to close before waiting for active connections.
+		if n := r.keepAlive.cancelAll(); n > 0 {
+			log.Info("mux: Cancelled long-lived requests", "count", n)
+		}
+
+		// Reject new connections, then let in-flight requests finish.
+		r.drain(server, ln)
+
 		ctx, cancel := context.WithTimeout(context.Background(), time.Duration(r.config.GracefulShutdown)*time.Second)
 		defer cancel()
 
@@ -0,0 +1,65 @@
+package mux
+
+import (
+	"context"
+	"sync"
+)
+
+// keepAliveRegistry tracks long-lived requests (e.g. SSE or WebSocket) so they can be
+// cancelled when the server shuts down, instead of blocking until the shutdown timeout.
+type keepAliveRegistry struct {
+	mu      sync.Mutex
+	next    uint64
+	cancels map[uint64]context.CancelFunc
+}
+
+// add registers cancel and returns a function removing it from the registry.
+func (reg *keepAliveRegistry) add(cancel context.CancelFunc) func() {
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+
+	if reg.cancels == nil {
+		reg.cancels = make(map[uint64]context.CancelFunc)
+	}
+
+	id := reg.next
+	reg.next++
+	reg.cancels[id] = cancel
+
+	return func() {
+		reg.mu.Lock()
+		defer reg.mu.Unlock()
+		delete(reg.cancels, id)
+	}
+}
+
+// cancelAll cancels all registered requests and returns how many were cancelled.
+func (reg *keepAliveRegistry) cancelAll() int {
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+
+	n := len(reg.cancels)
+	for id, cancel := range reg.cancels {
+		cancel()
+		delete(reg.cancels, id)
+	}
+	return n
+}
+
+// KeepAlive marks the request as long-lived (e.g. SSE or WebSocket).
+// Its context is cancelled when the server starts shutting down, so the handler
+// should return when ctx.Done() is closed. It has no effect when called more than once.
+func (ctx *Context) KeepAlive() {
+	if ctx.router == nil || ctx.release != nil {
+		return
+	}
+
+	c, cancel := context.WithCancel(ctx.Context)
+	ctx.Context = c
+
+	remove := ctx.router.keepAlive.add(cancel)
+	ctx.release = func() {
+		remove()
+		cancel()
+	}
+}
@@ -0,0 +1,40 @@
+package mux
+
+import (
+	"context"
+)
+
+// ContextKey is a typed key for request-scoped values stored on a Context.
+// Keys are compared by pointer, so values set by independently-developed
+// middleware never collide even when they use the same name.
+//
+// Middleware packages declare their keys once as package-level variables:
+//
+//	var TenantKey = mux.NewContextKey[string]("tenant")
+//
+//	mux.SetValue(ctx, TenantKey, "acme")
+//	tenant, ok := mux.Value(ctx, TenantKey)
+type ContextKey[T any] struct {
+	name string
+}
+
+// NewContextKey creates a new typed context key. The name is only used for debugging.
+func NewContextKey[T any](name string) *ContextKey[T] {
+	return &ContextKey[T]{name: name}
+}
+
+// String returns the name of the key.
+func (k *ContextKey[T]) String() string {
+	return "mux context key " + k.name
+}
+
+// SetValue stores val under key on the embedded context.Context of ctx.
+func SetValue[T any](ctx *Context, key *ContextKey[T], val T) {
+	ctx.Context = context.WithValue(ctx.Context, key, val)
+}
+
+// Value returns the value stored under key and whether it was found.
+func Value[T any](ctx context.Context, key *ContextKey[T]) (T, bool) {
+	val, ok := ctx.Value(key).(T)
+	return val, ok
+}
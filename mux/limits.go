@@ -0,0 +1,151 @@
+package mux
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/obadmatar/base/log"
+)
+
+// limitRequest rejects requests whose URI or query parameters exceed the configured limits
+// before they are dispatched to the handlers.
+func (r *router) limitRequest(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		// Check the raw URI length
+		if r.config.MaxURILength > 0 && len(req.RequestURI) > r.config.MaxURILength {
+			writeErrorResponse(w, http.StatusRequestURITooLong, CodeURITooLong, "Request URI is too long")
+			return
+		}
+
+		// Count query parameters without parsing them
+		if r.config.MaxQueryParams > 0 && countQueryParams(req.URL.RawQuery) > r.config.MaxQueryParams {
+			writeErrorResponse(w, http.StatusBadRequest, CodeDecodeError, "Too many query parameters")
+			return
+		}
+
+		next.ServeHTTP(w, req)
+	})
+}
+
+// countQueryParams returns the number of parameters in a raw query string.
+func countQueryParams(rawQuery string) int {
+	if rawQuery == "" {
+		return 0
+	}
+	return strings.Count(rawQuery, "&") + strings.Count(rawQuery, ";") + 1
+}
+
+// writeErrorResponse writes an ErrorResponse outside of a handler Context.
+func writeErrorResponse(w http.ResponseWriter, status int, code, message string) {
+	response := ErrorResponse{}
+	response.Error = code
+	response.Message = message
+	response.Status = status
+	if err := encode(w, status, response, nil); err != nil {
+		http.Error(w, message, status)
+	}
+}
+
+// HeaderTooLargeError is returned when the request headers exceed the allowed size.
+// It is answered with 431 Request Header Fields Too Large.
+type HeaderTooLargeError struct {
+	Limit int
+	Size  int
+}
+
+// Error implements builtin.error interface
+func (e *HeaderTooLargeError) Error() string {
+	return fmt.Sprintf("request headers must not exceed %d bytes", e.Limit)
+}
+
+// HeaderLimit returns a middleware rejecting requests whose headers exceed maxBytes
+// with a HeaderTooLargeError, answered in the standard ErrorResponse format.
+//
+// Requests exceeding Config.MaxHeaderBytes are rejected by net/http before any
+// handler runs with a plain-text 431 response. To get JSON responses instead, set
+// maxBytes below Config.MaxHeaderBytes so this middleware rejects them first.
+func HeaderLimit(maxBytes int) MiddlewareFunc {
+	return func(next Handler) Handler {
+		return HandlerFunc(func(ctx *Context) error {
+			if size := headerSize(ctx.req); size > maxBytes {
+				return &HeaderTooLargeError{Limit: maxBytes, Size: size}
+			}
+			return next.Handle(ctx)
+		})
+	}
+}
+
+// headerSize approximates the wire size of the request headers, as counted by net/http.
+func headerSize(r *http.Request) int {
+	size := len(r.Method) + len(r.RequestURI) + len(r.Proto) + 4
+	for key, values := range r.Header {
+		for _, value := range values {
+			size += len(key) + len(value) + 4 // ": " and "\r\n"
+		}
+	}
+	return size
+}
+
+// headerTooLargeErrorResponse builds the 431 response of oversized headers.
+func headerTooLargeErrorResponse(e *HeaderTooLargeError) ErrorResponse {
+	response := ErrorResponse{}
+	response.Error = CodeHeaderTooLarge
+	response.Message = e.Error()
+	response.Status = http.StatusRequestHeaderFieldsTooLarge
+	return response
+}
+
+// ConcurrencyLimit returns a middleware capping the number of requests handled concurrently to max.
+// Requests over the limit wait up to queueTimeout for a free slot, after which they are
+// rejected with 503 Service Unavailable. A queueTimeout of 0 rejects them immediately.
+// A max lower than 1 is logged and disables the limit.
+func ConcurrencyLimit(max int, queueTimeout time.Duration) MiddlewareFunc {
+	if max < 1 {
+		log.Error("mux: Invalid concurrency limit, disabling it", "limit", max)
+		return func(next Handler) Handler { return next }
+	}
+
+	sem := make(chan struct{}, max)
+
+	return func(next Handler) Handler {
+		return HandlerFunc(func(ctx *Context) error {
+			if !acquire(ctx, sem, queueTimeout) {
+				log.Warn("mux: Concurrency limit reached, rejecting request", "method", ctx.Method(), "url", ctx.URI(), "limit", max)
+				writeErrorResponse(ctx.rsp, http.StatusServiceUnavailable, CodeServiceUnavailable, "Server is busy, please retry later")
+				return nil
+			}
+
+			// release the slot on all exit paths, including panics
+			defer func() { <-sem }()
+
+			return next.Handle(ctx)
+		})
+	}
+}
+
+// acquire takes a slot from sem, waiting up to timeout or until the request is cancelled.
+func acquire(ctx *Context, sem chan struct{}, timeout time.Duration) bool {
+	select {
+	case sem <- struct{}{}:
+		return true
+	default:
+	}
+
+	if timeout <= 0 {
+		return false
+	}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case sem <- struct{}{}:
+		return true
+	case <-timer.C:
+		return false
+	case <-ctx.Done():
+		return false
+	}
+}
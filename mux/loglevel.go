@@ -0,0 +1,40 @@
+package mux
+
+import (
+	"net/http"
+
+	"github.com/obadmatar/base/log"
+)
+
+// logLevelRequest is the body accepted by LogLevelHandler to change the level.
+type logLevelRequest struct {
+	Level string `json:"level" validate:"required"`
+}
+
+// LogLevelHandler returns an admin handler reading and changing the level of the default logger
+// at runtime, e.g. to raise verbosity to DEBUG while investigating a live issue and revert it after.
+// GET responds with the current level, PUT and POST set it from a {"level": "DEBUG"} body.
+// It must be mounted behind an authentication middleware.
+func LogLevelHandler() HandlerFunc {
+	return func(ctx *Context) error {
+		switch ctx.Method() {
+		case http.MethodGet, http.MethodHead:
+		case http.MethodPut, http.MethodPost:
+			var req logLevelRequest
+			if err := ctx.Decode(&req); err != nil {
+				return err
+			}
+
+			if err := log.SetLevelFromString(req.Level); err != nil {
+				return &BindingError{Message: "Invalid Log Level", Errors: map[string]string{"level": "is invalid"}}
+			}
+			log.Warn("mux: Log level changed", "level", log.GetLevel().String(), "remote_addr", ctx.ClientIP())
+		default:
+			ctx.SetHeader("Allow", "GET, HEAD, PUT, POST")
+			writeErrorResponse(ctx.rsp, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "The request method is not allowed for this resource")
+			return nil
+		}
+
+		return ctx.OK(M{"level": log.GetLevel().String()})
+	}
+}
@@ -0,0 +1,27 @@
+package mux
+
+import "strings"
+
+// MethodFilter wraps the middleware mw so it only applies to requests with one of the given
+// methods, e.g. CSRF protection for state-changing requests only:
+//
+//	router.Use(mux.MethodFilter([]string{"POST", "PUT", "PATCH", "DELETE"}, csrf))
+//
+// For other methods mw is skipped entirely and the next handler is called directly.
+// Methods are matched case-insensitively.
+func MethodFilter(methods []string, mw MiddlewareFunc) MiddlewareFunc {
+	allowed := make(map[string]bool, len(methods))
+	for _, method := range methods {
+		allowed[strings.ToUpper(method)] = true
+	}
+
+	return func(next Handler) Handler {
+		filtered := mw(next)
+		return HandlerFunc(func(ctx *Context) error {
+			if allowed[ctx.Method()] {
+				return filtered.Handle(ctx)
+			}
+			return next.Handle(ctx)
+		})
+	}
+}
@@ -0,0 +1,171 @@
+package mux
+
+import (
+	"errors"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/obadmatar/base/valid"
+)
+
+// MultiError accumulates binding, validation and domain errors, so a handler can report
+// all the problems of a request at once instead of failing on the first one.
+// It is answered with a single 400 ErrorResponse merging every field error.
+//
+//	errs := mux.NewMultiError("Invalid Transfer")
+//	errs.Add(ctx.Decode(&req))
+//	if balance < req.Amount {
+//		errs.AddField("amount", "exceeds the available balance")
+//	}
+//	return errs.ErrOrNil()
+type MultiError struct {
+	// Message is the top-level message of the response, defaults to the joined Messages
+	Message string
+
+	// Errors maps fields to their error message
+	Errors map[string]string
+
+	// Messages holds the errors not tied to a field
+	Messages []string
+
+	// internal is the first unexpected error added, answered with a 500 instead
+	internal error
+}
+
+// NewMultiError creates an empty MultiError with the given top-level message.
+func NewMultiError(message string) *MultiError {
+	return &MultiError{Message: message, Errors: make(map[string]string)}
+}
+
+// AddField adds an error message for the given field.
+func (m *MultiError) AddField(field, message string) *MultiError {
+	if m.Errors == nil {
+		m.Errors = make(map[string]string)
+	}
+	m.Errors[field] = message
+	return m
+}
+
+// AddMessage adds an error message not tied to a field.
+func (m *MultiError) AddMessage(message string) *MultiError {
+	m.Messages = append(m.Messages, message)
+	return m
+}
+
+// Add merges err into m. Field errors of binding and validation errors are merged into Errors,
+// and domain errors are added under their code, or to Messages if they have none.
+// Other errors are unexpected, a MultiError holding one is answered with a 500.
+// Nil errors and results (Responder) are ignored, as they are not failures.
+func (m *MultiError) Add(err error) *MultiError {
+	var res Responder
+	if err == nil || errors.As(err, &res) {
+		return m
+	}
+
+	var multi *MultiError
+	var binding *BindingError
+	var validation valid.Errors
+	var notFound *NotFoundError
+	var domain *DomainError
+
+	switch {
+	case errors.As(err, &multi):
+		for field, message := range multi.Errors {
+			m.AddField(field, message)
+		}
+		m.Messages = append(m.Messages, multi.Messages...)
+		if m.internal == nil {
+			m.internal = multi.internal
+		}
+	case errors.As(err, &binding):
+		if len(binding.Errors) == 0 {
+			return m.AddMessage(binding.Message)
+		}
+		for field, message := range binding.Errors {
+			m.AddField(field, message)
+		}
+	case errors.As(err, &validation):
+		for field, message := range valid.ExtractFieldErrors(validation) {
+			m.AddField(field, message)
+		}
+	case errors.As(err, &notFound):
+		m.addDomainError(&notFound.DomainError)
+	case errors.As(err, &domain):
+		m.addDomainError(domain)
+	default:
+		if m.internal == nil {
+			m.internal = err
+		}
+	}
+
+	return m
+}
+
+// addDomainError adds a domain error under its code, or to Messages if it has none.
+func (m *MultiError) addDomainError(d *DomainError) {
+	if d.Code != "" {
+		m.AddField(d.Code, d.Message)
+		return
+	}
+	m.AddMessage(d.Message)
+}
+
+// HasErrors reports whether any error was added.
+func (m *MultiError) HasErrors() bool {
+	return len(m.Errors) > 0 || len(m.Messages) > 0 || m.internal != nil
+}
+
+// ErrOrNil returns m if any error was added, or nil, so it can be returned from a handler.
+func (m *MultiError) ErrOrNil() error {
+	if !m.HasErrors() {
+		return nil
+	}
+	return m
+}
+
+// Error implements builtin.error interface
+func (m *MultiError) Error() string {
+	parts := append([]string(nil), m.Messages...)
+
+	fields := make([]string, 0, len(m.Errors))
+	for field := range m.Errors {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	for _, field := range fields {
+		parts = append(parts, field+" "+m.Errors[field])
+	}
+
+	if m.internal != nil {
+		parts = append(parts, m.internal.Error())
+	}
+
+	return strings.Join(parts, "; ")
+}
+
+// message returns the top-level message of the response.
+func (m *MultiError) message() string {
+	if m.Message != "" {
+		return m.Message
+	}
+	if len(m.Messages) > 0 {
+		return strings.Join(m.Messages, "; ")
+	}
+	return "Invalid Request"
+}
+
+// multiErrorResponse builds the BadRequest response of aggregated errors merging all field errors.
+// The unexpected error added to m, if any, is returned instead, to be answered with a 500.
+func multiErrorResponse(m *MultiError) (ErrorResponse, error) {
+	if m.internal != nil {
+		return ErrorResponse{}, m.internal
+	}
+
+	response := ErrorResponse{}
+	response.Error = CodeMultipleErrors
+	response.Message = m.message()
+	response.Status = http.StatusBadRequest
+	response.Errors = m.Errors
+	return response, nil
+}
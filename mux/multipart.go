@@ -0,0 +1,87 @@
+package mux
+
+import (
+	"context"
+	"errors"
+	"mime"
+	"net/http"
+	"os"
+	"time"
+)
+
+// ParseMultipartFormCtx parses a multipart/form-data body like ParseMultipartForm, storing up
+// to maxMemory bytes in memory, but rejects bodies larger than maxTotal bytes with 413 and
+// stops reading when c is done (e.g. its deadline passes) with 408, to protect upload
+// endpoints from oversized and slow uploads. Both are reported as a BindingError.
+// It returns http.ErrNotMultipart if the request is not a multipart form.
+func (ctx *Context) ParseMultipartFormCtx(c context.Context, maxMemory, maxTotal int64) error {
+	r := ctx.req
+	if r.MultipartForm != nil {
+		return nil
+	}
+
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/form-data" {
+		return http.ErrNotMultipart
+	}
+
+	// reject early if the declared body size exceeds the limit
+	if err := checkContentLength(r, maxTotal); err != nil {
+		return err
+	}
+	if r.Body != nil {
+		r.Body = http.MaxBytesReader(ctx.rsp, r.Body, maxTotal)
+	}
+
+	// Interrupt blocked reads once c is done
+	rc := http.NewResponseController(ctx.rsp)
+	if deadline, ok := c.Deadline(); ok {
+		_ = rc.SetReadDeadline(deadline)
+	}
+	stop := context.AfterFunc(c, func() {
+		_ = rc.SetReadDeadline(time.Now())
+	})
+	defer func() {
+		stop()
+		_ = rc.SetReadDeadline(time.Time{})
+	}()
+
+	err = r.ParseMultipartForm(maxMemory)
+	if err == nil {
+		return nil
+	}
+
+	var maxBytesError *http.MaxBytesError
+	if errors.As(err, &maxBytesError) {
+		err := newBindingError("body must not exceed %d bytes", maxBytesError.Limit)
+		err.status = http.StatusRequestEntityTooLarge
+		return err
+	}
+
+	if errors.Is(err, os.ErrDeadlineExceeded) || c.Err() != nil {
+		err := newBindingError("multipart upload timed out")
+		err.status = http.StatusRequestTimeout
+		return err
+	}
+
+	return newBindingError("body contains a badly-formed form: %v", err)
+}
+
+// parseMultipartForm parses a multipart form using the configured limits.
+func (ctx *Context) parseMultipartForm() error {
+	c := ctx.Context
+	if ctx.config.MultipartTimeout > 0 {
+		var cancel context.CancelFunc
+		c, cancel = context.WithTimeout(c, time.Duration(ctx.config.MultipartTimeout)*time.Second)
+		defer cancel()
+	}
+	return ctx.ParseMultipartFormCtx(c, ctx.maxMultipartMemory(), ctx.maxMultipartBytes())
+}
+
+// maxMultipartBytes returns the configured multipart size limit or 100MB if unset.
+func (ctx *Context) maxMultipartBytes() int64 {
+	if ctx.config.MaxMultipartBytes > 0 {
+		return ctx.config.MaxMultipartBytes
+	}
+	return 100 << 20
+}
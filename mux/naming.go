@@ -0,0 +1,130 @@
+package mux
+
+import (
+	"strings"
+	"sync"
+	"sync/atomic"
+	"unicode"
+)
+
+// JSON naming strategies of Config.JSONNamingStrategy.
+const (
+	// JSONNamingNone sends the JSON keys as-is, from the json tags.
+	JSONNamingNone = "none"
+
+	// JSONNamingSnake converts the JSON keys to snake_case, e.g. "firstName" to "first_name".
+	JSONNamingSnake = "snake"
+
+	// JSONNamingCamel converts the JSON keys to camelCase, e.g. "first_name" to "firstName".
+	JSONNamingCamel = "camel"
+)
+
+// maxKeyCacheSize bounds each keyCache, as map keys (e.g. user IDs or emails) are converted
+// too and would otherwise grow it without limit. Keys past the bound are converted every time.
+const maxKeyCacheSize = 4096
+
+// keyCache caches the converted keys of a naming strategy, as responses repeat the same keys.
+type keyCache struct {
+	keys sync.Map
+	size atomic.Int64
+}
+
+var (
+	snakeCache keyCache
+	camelCache keyCache
+)
+
+// keyRenamer returns the function converting JSON keys for the given strategy, nil for none.
+func keyRenamer(strategy string) func(string) string {
+	var convert func(string) string
+	var cache *keyCache
+	switch strategy {
+	case JSONNamingSnake:
+		convert, cache = snakeCase, &snakeCache
+	case JSONNamingCamel:
+		convert, cache = camelCase, &camelCache
+	default:
+		return nil
+	}
+
+	return func(key string) string {
+		if v, found := cache.keys.Load(key); found {
+			return v.(string)
+		}
+
+		converted := convert(key)
+		if cache.size.Load() < maxKeyCacheSize {
+			if _, loaded := cache.keys.LoadOrStore(key, converted); !loaded {
+				cache.size.Add(1)
+			}
+		}
+		return converted
+	}
+}
+
+// isValidJSONNaming checks if the given string is a supported JSON naming strategy
+func isValidJSONNaming(strategy string) bool {
+	switch strategy {
+	case JSONNamingNone, JSONNamingSnake, JSONNamingCamel:
+		return true
+	}
+	return false
+}
+
+// snakeCase converts key to snake_case, keeping acronyms together, e.g. "userID" to "user_id"
+// and "HTTPServer" to "http_server".
+func snakeCase(key string) string {
+	runes := []rune(key)
+
+	var b strings.Builder
+	b.Grow(len(key) + 4)
+	for i, r := range runes {
+		if r == '-' || r == ' ' {
+			r = '_'
+		}
+		if unicode.IsUpper(r) && i > 0 {
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if prev != '_' && (unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower)) {
+				b.WriteByte('_')
+			}
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
+
+// camelCase converts key to camelCase, e.g. "first_name" to "firstName".
+// Keys without separators only get their leading capitals lowercased, e.g. "HTTPServer" to "httpServer".
+func camelCase(key string) string {
+	parts := strings.FieldsFunc(key, func(r rune) bool {
+		return r == '_' || r == '-' || r == ' '
+	})
+	if len(parts) == 0 {
+		return key
+	}
+
+	var b strings.Builder
+	b.Grow(len(key))
+	for i, part := range parts {
+		runes := []rune(part)
+		if i == 0 {
+			lowerInitials(runes)
+		} else {
+			runes[0] = unicode.ToUpper(runes[0])
+		}
+		b.WriteString(string(runes))
+	}
+	return b.String()
+}
+
+// lowerInitials lowercases the leading uppercase letters of runes, except the last one
+// starting a new word, e.g. "HTTPServer" to "httpServer".
+func lowerInitials(runes []rune) {
+	for i := 0; i < len(runes) && unicode.IsUpper(runes[i]); i++ {
+		if i > 0 && i+1 < len(runes) && unicode.IsLower(runes[i+1]) {
+			return
+		}
+		runes[i] = unicode.ToLower(runes[i])
+	}
+}
@@ -0,0 +1,38 @@
+package mux
+
+import "testing"
+
+func TestSnakeCase(t *testing.T) {
+	tests := map[string]string{
+		"firstName":  "first_name",
+		"userID":     "user_id",
+		"HTTPServer": "http_server",
+		"address2":   "address2",
+		"first_name": "first_name",
+		"zip-code":   "zip_code",
+	}
+	for key, want := range tests {
+		if got := snakeCase(key); got != want {
+			t.Errorf("snakeCase(%q) = %q, want %q", key, got, want)
+		}
+	}
+}
+
+func TestCamelCase(t *testing.T) {
+	tests := map[string]string{
+		"first_name": "firstName",
+		"user_id":    "userId",
+		"HTTPServer": "httpServer",
+		"firstName":  "firstName",
+		"zip-code":   "zipCode",
+	}
+	for key, want := range tests {
+		if got := camelCase(key); got != want {
+			t.Errorf("camelCase(%q) = %q, want %q", key, got, want)
+		}
+	}
+}
+
+func BenchmarkEncodeSnakeCase(b *testing.B) {
+	benchmarkEncode(b, func(rw *responseWriter) { rw.renameKey = keyRenamer(JSONNamingSnake) })
+}
@@ -1,7 +1,8 @@
 package mux
 
 import (
-	"fmt"
+	"io"
+	"sync"
 
 	"github.com/MarceloPetrucio/go-scalar-api-reference"
 
@@ -10,32 +11,45 @@ import (
 
 // ApiDocsHandler serves the API documentation in HTML format.
 // It uses the `go-scalar-api-reference` package to generate HTML content for the API documentation.
+// The HTML is generated on the first request and cached, since it only depends on specURL and pageTitle.
+// Generation failures are logged and answered with the standard 500 ErrorResponse.
 func ApiDocsHandler(specURL, pageTitle string) HandlerFunc {
-	return func(ctx *Context) error {
-		// Generate HTML
-		htmlContent, err := scalar.ApiReferenceHTML(&scalar.Options{
-
-			DarkMode: true,
-			Layout:   "classic",
-			Theme:    "alternate",
-
-			HideModels:         false,
-			ShowSidebar:        true,
-			HideDownloadButton: true,
+	var (
+		mu   sync.Mutex
+		html string
+	)
 
-			SpecURL:       specURL,
-			CustomOptions: scalar.CustomOptions{PageTitle: pageTitle},
-		})
-
-		if err != nil {
-			log.Error("openapi: ", "error", err)
-			return ctx.InternalServerError(M{"error": err})
+	return func(ctx *Context) error {
+		mu.Lock()
+		if html == "" {
+			// Generate HTML, a failed generation isn't cached so the next request retries
+			content, err := scalar.ApiReferenceHTML(&scalar.Options{
+
+				DarkMode: true,
+				Layout:   "classic",
+				Theme:    "alternate",
+
+				HideModels:         false,
+				ShowSidebar:        true,
+				HideDownloadButton: true,
+
+				SpecURL:       specURL,
+				CustomOptions: scalar.CustomOptions{PageTitle: pageTitle},
+			})
+			if err != nil {
+				mu.Unlock()
+				log.Error("openapi: Failed to generate API docs", "spec_url", specURL, "error", err)
+				ctx.internalServerErrorWith(err)
+				return nil
+			}
+			html = content + "\n"
 		}
+		content := html
+		mu.Unlock()
 
-		_, err = fmt.Fprintln(ctx.rsp, htmlContent)
-		if err != nil {
-			log.Error("openapi: ", "error", err)
-			return ctx.InternalServerError(M{"error": err})
+		ctx.SetHeader("Content-Type", "text/html; charset=utf-8")
+		if _, err := io.WriteString(ctx.rsp, content); err != nil {
+			log.Error("openapi: Failed to write API docs", "error", err)
 		}
 
 		return nil
@@ -0,0 +1,64 @@
+package mux
+
+import (
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// headerMethodOverride is the header carrying the intended method of a POST request.
+const headerMethodOverride = "X-HTTP-Method-Override"
+
+// MethodOverride returns a net/http middleware rewriting the method of POST requests carrying
+// an X-HTTP-Method-Override header or a _method form field, for clients behind proxies that
+// only allow GET and POST. Only overrides to PUT, PATCH and DELETE are honored.
+//
+// The _method field is only read from url-encoded bodies, limited to 1MB. Multipart forms are
+// never parsed before routing, so they must send the header, and the upload limits of
+// Context.DecodeForm keep applying to them.
+//
+// It must run before the request is routed, so it can't be registered with Router.Use.
+// Enable it with Config.MethodOverride, which applies it before routing with the
+// configured MaxBodyBytes.
+func MethodOverride() func(http.Handler) http.Handler {
+	return methodOverride(1 << 20)
+}
+
+// methodOverride returns the MethodOverride middleware, reading url-encoded bodies up to maxBytes.
+func methodOverride(maxBytes int64) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodPost {
+				if method := overrideMethod(w, r, maxBytes); method != "" {
+					r.Method = method
+				}
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// overrideMethod returns the allowed override method of r, or empty if none.
+// The _method field of url-encoded bodies is read up to maxBytes.
+func overrideMethod(w http.ResponseWriter, r *http.Request, maxBytes int64) string {
+	method := r.Header.Get(headerMethodOverride)
+
+	// Fallback to the _method field of url-encoded bodies
+	if method == "" && isURLEncodedForm(r) && r.Body != nil {
+		r.Body = http.MaxBytesReader(w, r.Body, maxBytes)
+		method = r.PostFormValue("_method")
+	}
+
+	switch method = strings.ToUpper(strings.TrimSpace(method)); method {
+	case http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return method
+	default:
+		return ""
+	}
+}
+
+// isURLEncodedForm reports whether the request body is an url-encoded form.
+func isURLEncodedForm(r *http.Request) bool {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	return err == nil && mediaType == "application/x-www-form-urlencoded"
+}
@@ -0,0 +1,53 @@
+package mux
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestMethodOverride(t *testing.T) {
+	var multipartBody bytes.Buffer
+	mw := multipart.NewWriter(&multipartBody)
+	_ = mw.WriteField("_method", "DELETE")
+	_ = mw.Close()
+
+	tests := []struct {
+		name        string
+		contentType string
+		header      string
+		body        string
+		want        string
+	}{
+		{"header", "", "DELETE", "", http.MethodDelete},
+		{"url-encoded field", "application/x-www-form-urlencoded", "", "_method=put", http.MethodPut},
+		{"multipart field", mw.FormDataContentType(), "", multipartBody.String(), http.MethodPost},
+		{"disallowed method", "", "GET", "", http.MethodPost},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/users/1", strings.NewReader(tt.body))
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+			if tt.header != "" {
+				req.Header.Set(headerMethodOverride, tt.header)
+			}
+
+			var got *http.Request
+			h := MethodOverride()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { got = r }))
+			h.ServeHTTP(httptest.NewRecorder(), req)
+
+			if got.Method != tt.want {
+				t.Errorf("method = %s, want %s", got.Method, tt.want)
+			}
+			if got.MultipartForm != nil {
+				t.Error("multipart form parsed before routing")
+			}
+		})
+	}
+}
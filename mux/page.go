@@ -0,0 +1,41 @@
+package mux
+
+// Page is the standard envelope of paginated list responses.
+// Offset-based endpoints set Total, Limit and Offset, cursor-based endpoints set NextCursor.
+type Page[T any] struct {
+	Items      []T    `json:"items"`
+	Total      int    `json:"total"`
+	Limit      int    `json:"limit"`
+	Offset     int    `json:"offset"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	HasMore    bool   `json:"has_more"`
+}
+
+// NewPage creates an offset-based Page, computing HasMore from total, limit and offset.
+func NewPage[T any](items []T, total, limit, offset int) Page[T] {
+	return Page[T]{
+		Items:   items,
+		Total:   total,
+		Limit:   limit,
+		Offset:  offset,
+		HasMore: HasMore(total, limit, offset),
+	}
+}
+
+// HasMore reports whether items remain after the page of the given limit starting at offset.
+// It takes its arguments in the same order as NewPage.
+func HasMore(total, limit, offset int) bool {
+	return offset+limit < total
+}
+
+// SendPage sends page as a JSON response with the given status, or 200 OK if status is 0.
+// Nil items are sent as an empty array, and HasMore is set when NextCursor is set.
+func SendPage[T any](ctx *Context, status int, page Page[T]) error {
+	if page.Items == nil {
+		page.Items = []T{}
+	}
+	if page.NextCursor != "" {
+		page.HasMore = true
+	}
+	return encode(ctx.rsp, status, page, nil)
+}
@@ -0,0 +1,26 @@
+package mux
+
+import "testing"
+
+func TestNewPageHasMore(t *testing.T) {
+	tests := []struct {
+		total, limit, offset int
+		want                 bool
+	}{
+		{total: 25, limit: 10, offset: 0, want: true},
+		{total: 25, limit: 10, offset: 10, want: true},
+		{total: 25, limit: 10, offset: 20, want: false},
+		{total: 20, limit: 10, offset: 10, want: false},
+		{total: 0, limit: 10, offset: 0, want: false},
+	}
+
+	for _, tt := range tests {
+		page := NewPage([]int{}, tt.total, tt.limit, tt.offset)
+		if page.HasMore != tt.want || HasMore(tt.total, tt.limit, tt.offset) != tt.want {
+			t.Errorf("HasMore(total=%d, limit=%d, offset=%d) = %v, want %v", tt.total, tt.limit, tt.offset, page.HasMore, tt.want)
+		}
+		if page.Limit != tt.limit || page.Offset != tt.offset {
+			t.Errorf("NewPage(limit=%d, offset=%d) set limit %d and offset %d", tt.limit, tt.offset, page.Limit, page.Offset)
+		}
+	}
+}
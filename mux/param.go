@@ -0,0 +1,60 @@
+package mux
+
+import (
+	"reflect"
+	"strconv"
+
+	"github.com/google/uuid"
+)
+
+// ParamType is the set of types supported by Param.
+type ParamType interface {
+	~string | ~int | ~int64 | ~bool | uuid.UUID
+}
+
+// Param returns the named path value converted to T, e.g. mux.Param[int64](ctx, "id").
+// Unlike PathInt, it returns a BindingError when the value is missing or can't be converted,
+// so returning it from a handler results in a 400 Bad Request response.
+func Param[T ParamType](ctx *Context, name string) (T, error) {
+	var v T
+
+	value := ctx.PathValue(name)
+	if value == "" {
+		return v, paramError(name, "is required")
+	}
+
+	// uuid.UUID is an array, so it's matched by type before the kinds below
+	if id, ok := any(&v).(*uuid.UUID); ok {
+		parsed, err := uuid.Parse(value)
+		if err != nil {
+			return v, paramError(name, "must be a valid UUID")
+		}
+		*id = parsed
+		return v, nil
+	}
+
+	rv := reflect.ValueOf(&v).Elem()
+	switch rv.Kind() {
+	case reflect.String:
+		rv.SetString(value)
+	case reflect.Int, reflect.Int64:
+		n, err := strconv.ParseInt(value, 10, rv.Type().Bits())
+		if err != nil {
+			return v, paramError(name, "must be a valid integer")
+		}
+		rv.SetInt(n)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return v, paramError(name, "must be a valid boolean")
+		}
+		rv.SetBool(b)
+	}
+
+	return v, nil
+}
+
+// paramError returns a BindingError for the named path value.
+func paramError(name, message string) *BindingError {
+	return &BindingError{Message: "Invalid Path Params", Errors: map[string]string{name: message}}
+}
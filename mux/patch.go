@@ -0,0 +1,86 @@
+package mux
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ApplyMergePatch applies a JSON Merge Patch (RFC 7396) document onto the original
+// JSON document and returns the merged result.
+// Object members set to null in the patch are removed from the original, objects are
+// merged recursively and any other value in the patch replaces the original value.
+func ApplyMergePatch(original, patch []byte) ([]byte, error) {
+	var patchDoc any
+	if err := unmarshalJSON(patch, &patchDoc); err != nil {
+		return nil, newBindingError("patch contains badly-formed JSON and can not be parsed")
+	}
+
+	var originalDoc any
+	if len(bytes.TrimSpace(original)) > 0 {
+		if err := unmarshalJSON(original, &originalDoc); err != nil {
+			return nil, fmt.Errorf("merge patch: original document is not valid JSON: %w", err)
+		}
+	}
+
+	return json.Marshal(mergePatch(originalDoc, patchDoc))
+}
+
+// MergePatch reads the request body as a JSON Merge Patch (RFC 7396) and applies it onto current.
+// current is encoded to JSON before the patch is applied; the merged document is returned so it
+// can be decoded into the target type and validated.
+func (ctx *Context) MergePatch(current any) ([]byte, error) {
+	original, err := json.Marshal(current)
+	if err != nil {
+		return nil, fmt.Errorf("merge patch: failed to encode current document: %w", err)
+	}
+
+	patch, err := readBody(ctx.rsp, ctx.req, ctx.maxBodyBytes())
+	if err != nil {
+		return nil, err
+	}
+
+	if len(bytes.TrimSpace(patch)) == 0 {
+		return nil, newBindingError("body must be valid JSON")
+	}
+
+	return ApplyMergePatch(original, patch)
+}
+
+// mergePatch implements the MergePatch(Target, Patch) function defined in RFC 7396.
+func mergePatch(target, patch any) any {
+	patchObj, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+
+	targetObj, ok := target.(map[string]any)
+	if !ok {
+		targetObj = make(map[string]any)
+	}
+
+	for name, value := range patchObj {
+		if value == nil {
+			delete(targetObj, name)
+			continue
+		}
+		targetObj[name] = mergePatch(targetObj[name], value)
+	}
+
+	return targetObj
+}
+
+// unmarshalJSON decodes a single JSON value from data preserving number precision.
+func unmarshalJSON(data []byte, v any) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(v); err != nil {
+		return err
+	}
+	if decoder.Decode(&struct{}{}) != io.EOF {
+		return errors.New("document must only contain a single JSON value")
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package mux
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// TestApplyMergePatch runs the examples of RFC 7396, Appendix A.
+func TestApplyMergePatch(t *testing.T) {
+	tests := []struct {
+		original, patch, want string
+	}{
+		{`{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"b"}`, `{"b":"c"}`, `{"a":"b","b":"c"}`},
+		{`{"a":"b"}`, `{"a":null}`, `{}`},
+		{`{"a":"b","b":"c"}`, `{"a":null}`, `{"b":"c"}`},
+		{`{"a":["b"]}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"c"}`, `{"a":["b"]}`, `{"a":["b"]}`},
+		{`{"a":{"b":"c"}}`, `{"a":{"b":"d","c":null}}`, `{"a":{"b":"d"}}`},
+		{`{"a":[{"b":"c"}]}`, `{"a":[1]}`, `{"a":[1]}`},
+		{`["a","b"]`, `["c","d"]`, `["c","d"]`},
+		{`{"a":"b"}`, `["c"]`, `["c"]`},
+		{`{"a":"foo"}`, `null`, `null`},
+		{`{"a":"foo"}`, `"bar"`, `"bar"`},
+		{`{"e":null}`, `{"a":1}`, `{"e":null,"a":1}`},
+		{`[1,2]`, `{"a":"b","c":null}`, `{"a":"b"}`},
+		{`{}`, `{"a":{"bb":{"ccc":null}}}`, `{"a":{"bb":{}}}`},
+	}
+
+	for _, tt := range tests {
+		got, err := ApplyMergePatch([]byte(tt.original), []byte(tt.patch))
+		if err != nil {
+			t.Errorf("ApplyMergePatch(%s, %s) error: %v", tt.original, tt.patch, err)
+			continue
+		}
+		if !jsonEqual(t, got, []byte(tt.want)) {
+			t.Errorf("ApplyMergePatch(%s, %s) = %s, want %s", tt.original, tt.patch, got, tt.want)
+		}
+	}
+}
+
+func TestApplyMergePatchInvalidPatch(t *testing.T) {
+	_, err := ApplyMergePatch([]byte(`{"a":"b"}`), []byte(`{"a":`))
+
+	var bindingErr *BindingError
+	if !errors.As(err, &bindingErr) {
+		t.Fatalf("ApplyMergePatch with a malformed patch: got %v, want a BindingError", err)
+	}
+}
+
+// jsonEqual reports whether a and b hold the same JSON value, ignoring member order.
+func jsonEqual(t *testing.T, a, b []byte) bool {
+	t.Helper()
+
+	var va, vb any
+	if err := json.Unmarshal(a, &va); err != nil {
+		t.Fatalf("invalid JSON %s: %v", a, err)
+	}
+	if err := json.Unmarshal(b, &vb); err != nil {
+		t.Fatalf("invalid JSON %s: %v", b, err)
+	}
+	return reflect.DeepEqual(va, vb)
+}
@@ -0,0 +1,102 @@
+package mux
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+// CheckPrecondition reports whether the If-Match precondition of the request holds for the current
+// ETag of the resource, for optimistic locking: handlers call it before applying an update, and
+// answer with PreconditionFailed when it doesn't hold, since the client edited a stale version.
+//
+// currentETag is the value the ETag header would have, e.g. `"v42"` or `W/"v42"`; unquoted values
+// are quoted, and an empty value means the resource doesn't exist. The precondition holds when the
+// request has no If-Match header, when it is "*" and the resource exists, or when one of its tags
+// matches with the strong comparison: both tags must be strong (no W/ prefix) and equal, so a weak
+// ETag never satisfies If-Match. A malformed header is returned as a BindingError.
+func (ctx *Context) CheckPrecondition(currentETag string) (bool, error) {
+	values := ctx.req.Header.Values("If-Match")
+	if len(values) == 0 {
+		return true, nil
+	}
+
+	current := currentETag
+	if current != "" && !strings.HasSuffix(current, `"`) {
+		current = `"` + current + `"`
+	}
+
+	tags, ok := parseETags(strings.Join(values, ","))
+	if !ok {
+		return false, newBindingError("If-Match header is malformed")
+	}
+
+	for _, tag := range tags {
+		if tag == "*" {
+			return currentETag != "", nil
+		}
+		if !strings.HasPrefix(tag, "W/") && !strings.HasPrefix(current, "W/") && tag == current {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// CheckUnmodifiedSince reports whether the If-Unmodified-Since precondition of the request holds
+// for the last modification time of the resource. It holds when the header is missing or invalid,
+// as required by RFC 9110, or when the resource wasn't modified after the given date.
+// If-Match takes precedence when both are sent, so use it only without an If-Match header.
+func (ctx *Context) CheckUnmodifiedSince(lastModified time.Time) bool {
+	since, err := http.ParseTime(ctx.req.Header.Get("If-Unmodified-Since"))
+	if err != nil {
+		return true
+	}
+	return !lastModified.Truncate(time.Second).After(since)
+}
+
+// PreconditionFailed sends a 412 Precondition Failed ErrorResponse, for updates whose
+// CheckPrecondition or CheckUnmodifiedSince doesn't hold.
+func (ctx *Context) PreconditionFailed() error {
+	response := ErrorResponse{}
+	response.Error = CodePreconditionFailed
+	response.Message = "The resource was modified, reload it and retry"
+	response.Status = http.StatusPreconditionFailed
+	return encode(ctx.rsp, http.StatusPreconditionFailed, response, nil)
+}
+
+// parseETags parses a comma-separated list of entity tags (e.g. `"a", W/"b"`) or "*",
+// reporting whether the list is well-formed.
+func parseETags(s string) ([]string, bool) {
+	var tags []string
+	for {
+		s = strings.TrimLeft(s, " \t,")
+		if s == "" {
+			return tags, len(tags) > 0
+		}
+
+		if s[0] == '*' {
+			tags = append(tags, "*")
+			s = s[1:]
+			continue
+		}
+
+		weak := strings.HasPrefix(s, "W/")
+		if weak {
+			s = s[2:]
+		}
+		if !strings.HasPrefix(s, `"`) {
+			return nil, false
+		}
+		end := strings.IndexByte(s[1:], '"')
+		if end < 0 {
+			return nil, false
+		}
+
+		tag := s[:end+2]
+		if weak {
+			tag = "W/" + tag
+		}
+		tags = append(tags, tag)
+		s = s[end+2:]
+	}
+}
@@ -0,0 +1,65 @@
+package mux
+
+import (
+	"strings"
+)
+
+// ResourceHandler is a REST resource registered with Resource.
+// It implements any of the Lister, Getter, Creator, Updater and Deleter interfaces;
+// actions it does not implement are not registered.
+type ResourceHandler any
+
+// Lister handles GET /name.
+type Lister interface {
+	List(ctx *Context) error
+}
+
+// Getter handles GET /name/{id}.
+type Getter interface {
+	Get(ctx *Context) error
+}
+
+// Creator handles POST /name.
+type Creator interface {
+	Create(ctx *Context) error
+}
+
+// Updater handles PUT /name/{id}.
+type Updater interface {
+	Update(ctx *Context) error
+}
+
+// Deleter handles DELETE /name/{id}.
+type Deleter interface {
+	Delete(ctx *Context) error
+}
+
+// Resource registers the CRUD routes of a resource implemented by h:
+//
+//   - GET    /name       → List
+//   - GET    /name/{id}  → Get
+//   - POST   /name       → Create
+//   - PUT    /name/{id}  → Update
+//   - DELETE /name/{id}  → Delete
+//
+// The {id} path value is available with Context.PathID.
+func Resource(router Router, name string, h ResourceHandler) {
+	path := "/" + strings.Trim(name, "/")
+	item := path + "/{id}"
+
+	if l, ok := h.(Lister); ok {
+		router.Handle("GET "+path, HandlerFunc(l.List))
+	}
+	if g, ok := h.(Getter); ok {
+		router.Handle("GET "+item, HandlerFunc(g.Get))
+	}
+	if c, ok := h.(Creator); ok {
+		router.Handle("POST "+path, HandlerFunc(c.Create))
+	}
+	if u, ok := h.(Updater); ok {
+		router.Handle("PUT "+item, HandlerFunc(u.Update))
+	}
+	if d, ok := h.(Deleter); ok {
+		router.Handle("DELETE "+item, HandlerFunc(d.Delete))
+	}
+}
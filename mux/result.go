@@ -0,0 +1,77 @@
+package mux
+
+import (
+	"net/http"
+
+	"github.com/obadmatar/base/log"
+)
+
+// Responder is implemented by result types a handler returns to send a response
+// with a status inferred from the type, instead of calling a Context method:
+//
+//	func (h *UserHandler) Create(ctx *mux.Context) error {
+//		...
+//		return mux.Created[User]{Value: user}
+//	}
+//
+// Results travel through the handler's error return value but are not errors;
+// handleRequest sends them as successful responses, also when wrapped with %w.
+// Middleware inspecting the returned error must check for a Responder with errors.As
+// before treating it as a failure, and must not log it as one.
+type Responder interface {
+	error
+
+	// StatusCode returns the HTTP status of the response.
+	StatusCode() int
+
+	// ResponseBody returns the value encoded as the response body, nil for no body.
+	ResponseBody() any
+}
+
+// OK is a result sent as 200 OK with Value as the body.
+type OK[T any] struct {
+	Value T
+}
+
+func (r OK[T]) Error() string     { return http.StatusText(http.StatusOK) }
+func (r OK[T]) StatusCode() int   { return http.StatusOK }
+func (r OK[T]) ResponseBody() any { return r.Value }
+
+// Created is a result sent as 201 Created with Value as the body.
+type Created[T any] struct {
+	Value T
+}
+
+func (r Created[T]) Error() string     { return http.StatusText(http.StatusCreated) }
+func (r Created[T]) StatusCode() int   { return http.StatusCreated }
+func (r Created[T]) ResponseBody() any { return r.Value }
+
+// Accepted is a result sent as 202 Accepted with Value as the body.
+type Accepted[T any] struct {
+	Value T
+}
+
+func (r Accepted[T]) Error() string     { return http.StatusText(http.StatusAccepted) }
+func (r Accepted[T]) StatusCode() int   { return http.StatusAccepted }
+func (r Accepted[T]) ResponseBody() any { return r.Value }
+
+// NoContent is a result sent as 204 No Content without a body.
+type NoContent struct{}
+
+func (r NoContent) Error() string     { return http.StatusText(http.StatusNoContent) }
+func (r NoContent) StatusCode() int   { return http.StatusNoContent }
+func (r NoContent) ResponseBody() any { return nil }
+
+// sendResult sends a handler result with its inferred status.
+func sendResult(ctx *Context, r Responder) {
+	body := r.ResponseBody()
+	if body == nil {
+		ctx.WriteHeader(r.StatusCode())
+		return
+	}
+
+	if err := encode(ctx.rsp, r.StatusCode(), body, nil); err != nil {
+		log.Error("mux: failed to respond", "error", err)
+		ctx.internalServerError()
+	}
+}
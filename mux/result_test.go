@@ -0,0 +1,26 @@
+package mux
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWrappedResponder(t *testing.T) {
+	h := HandlerFunc(func(ctx *Context) error {
+		return fmt.Errorf("audit: %w", Created[M]{Value: M{"id": 1}})
+	})
+
+	rec := TestHandler(h, httptest.NewRequest(http.MethodPost, "/users", nil))
+	if rec.Code != http.StatusCreated {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusCreated)
+	}
+}
+
+func TestMultiErrorIgnoresResponder(t *testing.T) {
+	errs := NewMultiError("Invalid Request").Add(OK[M]{Value: M{}})
+	if err := errs.ErrOrNil(); err != nil {
+		t.Errorf("ErrOrNil() = %v, want nil", err)
+	}
+}
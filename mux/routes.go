@@ -0,0 +1,66 @@
+package mux
+
+import (
+	"reflect"
+	"sort"
+)
+
+// RouteDoc describes a route for API documentation and route listings.
+// Request and Response reference the body types of the route, e.g. CreateUserRequest{}
+// or []User{}, and are meant as the source for OpenAPI spec generation.
+type RouteDoc struct {
+	Summary     string   `json:"summary,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
+	Request     any      `json:"-"`
+	Response    any      `json:"-"`
+}
+
+// RequestType returns the type of the documented request body, or nil if unset.
+func (d RouteDoc) RequestType() reflect.Type {
+	return typeOf(d.Request)
+}
+
+// ResponseType returns the type of the documented response body, or nil if unset.
+func (d RouteDoc) ResponseType() reflect.Type {
+	return typeOf(d.Response)
+}
+
+// Route describes a registered route, as returned by Router.Routes.
+// Doc is nil for routes registered without documentation.
+type Route struct {
+	Pattern string    `json:"pattern"`
+	Doc     *RouteDoc `json:"doc,omitempty"`
+}
+
+// route is a registered handler along with its optional documentation.
+type route struct {
+	handler Handler
+	doc     *RouteDoc
+}
+
+// Routes returns the registered routes with their documentation, sorted by pattern.
+func (r *router) Routes() []Route {
+	routes := make([]Route, 0, len(r.handlers))
+	for pattern, route := range r.handlers {
+		routes = append(routes, Route{Pattern: pattern, Doc: route.doc})
+	}
+
+	sort.Slice(routes, func(i, j int) bool {
+		return routes[i].Pattern < routes[j].Pattern
+	})
+
+	return routes
+}
+
+// typeOf returns the type of v, dereferencing pointers, or nil if v is nil.
+func typeOf(v any) reflect.Type {
+	if v == nil {
+		return nil
+	}
+	t := reflect.TypeOf(v)
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
@@ -0,0 +1,67 @@
+package mux
+
+import (
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/obadmatar/base/log"
+)
+
+// ReadinessHandler returns a handler answering readiness probes with 200 OK,
+// or 503 Service Unavailable once the server is shutting down.
+func (r *router) ReadinessHandler() Handler {
+	return HandlerFunc(func(ctx *Context) error {
+		if r.draining.Load() {
+			writeErrorResponse(ctx.rsp, http.StatusServiceUnavailable, CodeServiceUnavailable, "Server is shutting down")
+			return nil
+		}
+		return ctx.OK(M{"status": "ready"})
+	})
+}
+
+// drain closes the listener so no new connections are accepted, then waits up to the
+// configured ShutdownDrainPeriod for in-flight requests to finish, logging their count.
+func (r *router) drain(server *http.Server, ln net.Listener) {
+	r.draining.Store(true)
+
+	// Close connections once their current request is answered
+	server.SetKeepAlivesEnabled(false)
+	if err := ln.Close(); err != nil {
+		log.Warn("mux: Failed to close listener", "error", err)
+	}
+
+	period := time.Duration(r.config.ShutdownDrainPeriod) * time.Second
+	if period <= 0 {
+		return
+	}
+
+	interval := time.Duration(r.config.ShutdownDrainLogInterval) * time.Second
+	if interval <= 0 {
+		interval = time.Second
+	}
+
+	log.Info("mux: Draining in-flight requests", "in_flight", r.inFlight.Load(), "period", period)
+
+	deadline := time.NewTimer(period)
+	defer deadline.Stop()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		n := r.inFlight.Load()
+		if n == 0 {
+			log.Info("mux: All in-flight requests finished")
+			return
+		}
+
+		select {
+		case <-deadline.C:
+			log.Warn("mux: Drain period elapsed with requests in flight", "in_flight", n)
+			return
+		case <-ticker.C:
+			log.Info("mux: Draining in-flight requests", "in_flight", r.inFlight.Load())
+		}
+	}
+}
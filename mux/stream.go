@@ -0,0 +1,116 @@
+package mux
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/obadmatar/base/log"
+)
+
+// streamFlushEvery is the number of items written between flushes of a streamed array.
+const streamFlushEvery = 100
+
+// StreamJSONArray writes the items received from the channel as a JSON array, encoding
+// and sending them one at a time, so large result sets are not buffered in memory.
+// The producer must close the channel once all items are sent.
+//
+// The status is sent before the first item, so an error while streaming (e.g. an item
+// failing to encode, or the client disconnecting) can't change the response anymore.
+// In that case the array is left unterminated, the remaining items are drained, and the
+// error is returned to be logged by the router, without sending an error response.
+// Client disconnects (e.g. broken pipes) are logged at debug level as normal.
+func (ctx *Context) StreamJSONArray(status int, items <-chan any) error {
+	ctx.setJSONContentType()
+	ctx.WriteHeader(ctx.rsp.statusOrDefault(status))
+
+	if err := ctx.streamJSONArray(items); err != nil {
+		// unblock the producer
+		go func() {
+			for range items {
+			}
+		}()
+		return err
+	}
+
+	return nil
+}
+
+// streamJSONArray writes the items as a JSON array, flushing every streamFlushEvery items.
+func (ctx *Context) streamJSONArray(items <-chan any) error {
+	if _, err := ctx.Write([]byte("[")); err != nil {
+		return err
+	}
+
+	n := 0
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+
+		case item, ok := <-items:
+			if !ok {
+				_, err := ctx.Write([]byte("]"))
+				ctx.Flush()
+				return err
+			}
+
+			b, err := json.Marshal(item)
+			if err != nil {
+				return fmt.Errorf("encode item %d: %w", n, err)
+			}
+			if n > 0 {
+				b = append([]byte(","), b...)
+			}
+			if _, err := ctx.Write(b); err != nil {
+				return err
+			}
+
+			n++
+			if n%streamFlushEvery == 0 {
+				ctx.Flush()
+			}
+		}
+	}
+}
+
+// JSONStream sends body as JSON with the given status (0 for the SetStatus one or 200 OK),
+// encoding it with a json.Encoder writing to the response. The encoder reuses pooled buffers
+// instead of allocating a copy of every marshaled body, reducing the memory footprint of
+// large responses compared to the buffered methods (Send, OK...).
+//
+// The tradeoff is error-safety: a body failing to marshal is still detected before anything
+// is written, but the response is committed by the first write, so a failure while writing
+// (e.g. the client disconnecting) leaves a truncated response that can't be replaced.
+// Response transformers apply, but Config.CompactJSON and Config.JSONNamingStrategy don't,
+// since they rewrite the whole marshaled body.
+func (ctx *Context) JSONStream(status int, body any) error {
+	if ctx.rsp.transform != nil {
+		body = ctx.rsp.transform(body)
+	}
+
+	// The status is written along with the first bytes of the body
+	ctx.setJSONContentType()
+	ctx.rsp.pending = ctx.rsp.statusOrDefault(status)
+
+	if err := json.NewEncoder(ctx.rsp).Encode(body); err != nil {
+		// Nothing was written if the body failed to encode, so an error response can still be sent
+		if !ctx.rsp.Written() {
+			log.Error("mux: Failed to encode response body", "type", fmt.Sprintf("%T", body), "error", err)
+			return &EncodeError{Type: fmt.Sprintf("%T", body), Err: err}
+		}
+		return err
+	}
+
+	return nil
+}
+
+// setJSONContentType sets the configured Content-Type of JSON responses, unless already set.
+func (ctx *Context) setJSONContentType() {
+	if ctx.rsp.Header().Get("Content-Type") == "" {
+		contentType := ctx.rsp.contentType
+		if contentType == "" {
+			contentType = defaultContentType
+		}
+		ctx.SetHeader("Content-Type", contentType)
+	}
+}
@@ -0,0 +1,37 @@
+package mux
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+)
+
+// TestConfig returns a DefaultConfig suited for tests: the server only listens on the
+// loopback interface and shuts down within a second.
+func TestConfig() *Config {
+	config := DefaultConfig()
+	config.Host = "127.0.0.1"
+	config.GracefulShutdown = 1
+	return config
+}
+
+// NewTestContext creates a Context for the given request, recording the response,
+// for unit-testing handlers without starting a server.
+func NewTestContext(method, target string, body io.Reader) (*Context, *httptest.ResponseRecorder) {
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(method, target, body)
+	return newContext(rec, req, TestConfig()), rec
+}
+
+// TestHandler runs h against req through the same error-mapping path as the router,
+// and returns the recorded response.
+func TestHandler(h Handler, req *http.Request) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	r := &router{config: TestConfig()}
+
+	ctx := newContext(rec, req, r.config)
+	ctx.router = r
+	r.handleRequest(ctx, h)
+
+	return rec
+}
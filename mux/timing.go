@@ -0,0 +1,81 @@
+package mux
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Timing is a named duration recorded within a request with Context.StartSpan.
+type Timing struct {
+	Name     string
+	Duration time.Duration
+}
+
+// StartSpan starts timing a named step of the request (e.g. "db" or "cache") and returns
+// the function stopping it, recording the duration on the Context:
+//
+//	stop := ctx.StartSpan("db")
+//	users, err := repo.List(ctx)
+//	stop()
+//
+// Calling stop more than once records the span once. Spans can be recorded from goroutines
+// spawned by the handler, as long as they're stopped before the handler returns.
+func (ctx *Context) StartSpan(name string) func() {
+	start := time.Now()
+	stopped := false
+
+	return func() {
+		ctx.timingsMu.Lock()
+		defer ctx.timingsMu.Unlock()
+
+		if stopped {
+			return
+		}
+		stopped = true
+		ctx.timings = append(ctx.timings, Timing{Name: name, Duration: time.Since(start)})
+	}
+}
+
+// Timings returns the spans recorded with StartSpan, in the order they were stopped,
+// e.g. for middleware logging a latency breakdown.
+func (ctx *Context) Timings() []Timing {
+	ctx.timingsMu.Lock()
+	defer ctx.timingsMu.Unlock()
+
+	return append([]Timing(nil), ctx.timings...)
+}
+
+// ServerTiming sets the Server-Timing response header from the spans recorded with StartSpan,
+// e.g. "db;dur=12.4, cache;dur=3.1", for browser developer tools, and returns its value.
+// Durations are in milliseconds, and names are reduced to the characters allowed in tokens.
+// It must be called before the response is written, and does nothing without spans.
+func (ctx *Context) ServerTiming() string {
+	timings := ctx.Timings()
+	if len(timings) == 0 {
+		return ""
+	}
+
+	metrics := make([]string, 0, len(timings))
+	for _, t := range timings {
+		ms := strconv.FormatFloat(float64(t.Duration)/float64(time.Millisecond), 'f', 1, 64)
+		metrics = append(metrics, serverTimingName(t.Name)+";dur="+ms)
+	}
+
+	value := strings.Join(metrics, ", ")
+	ctx.SetHeader("Server-Timing", value)
+	return value
+}
+
+// serverTimingName replaces the characters not allowed in a header token with underscores.
+func serverTimingName(name string) string {
+	if name == "" {
+		return "span"
+	}
+	return strings.Map(func(r rune) rune {
+		if r > 0x7e || r <= ' ' || strings.ContainsRune(`"(),/:;<=>?@[\]{}`, r) {
+			return '_'
+		}
+		return r
+	}, name)
+}
@@ -0,0 +1,115 @@
+package mux
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"strings"
+)
+
+// W3C Trace Context headers, see https://www.w3.org/TR/trace-context/
+const (
+	headerTraceParent = "traceparent"
+	headerTraceState  = "tracestate"
+)
+
+// traceContext holds the W3C trace context of a request.
+type traceContext struct {
+	traceID      string // 32 hex chars identifying the whole trace
+	spanID       string // 16 hex chars identifying the span of this request
+	parentSpanID string // 16 hex chars of the caller's span, empty if the trace started here
+	flags        string // 2 hex chars of trace flags (e.g. "01" sampled)
+	state        string // vendor-specific tracestate, propagated as-is
+}
+
+// newTraceContext reads the traceparent and tracestate headers of r.
+// A new trace is started if traceparent is missing or invalid.
+// A new span ID is always generated for the current request.
+func newTraceContext(r *http.Request) traceContext {
+	tc, ok := parseTraceParent(r.Header.Get(headerTraceParent))
+	if !ok {
+		return traceContext{traceID: randomHex(16), spanID: randomHex(8), flags: "00"}
+	}
+
+	tc.state = r.Header.Get(headerTraceState)
+	tc.spanID = randomHex(8)
+	return tc
+}
+
+// parseTraceParent parses a traceparent header value: version-traceid-parentid-flags.
+func parseTraceParent(value string) (traceContext, bool) {
+	parts := strings.Split(strings.TrimSpace(value), "-")
+	if len(parts) < 4 {
+		return traceContext{}, false
+	}
+
+	version, traceID, parentID, flags := parts[0], parts[1], parts[2], parts[3]
+
+	// version ff is invalid, version 00 must have exactly 4 parts
+	if !isHex(version, 2) || version == "ff" || (version == "00" && len(parts) != 4) {
+		return traceContext{}, false
+	}
+
+	// all-zero trace and parent IDs are invalid
+	if !isHex(traceID, 32) || traceID == strings.Repeat("0", 32) {
+		return traceContext{}, false
+	}
+	if !isHex(parentID, 16) || parentID == strings.Repeat("0", 16) {
+		return traceContext{}, false
+	}
+	if !isHex(flags, 2) {
+		return traceContext{}, false
+	}
+
+	return traceContext{traceID: traceID, parentSpanID: parentID, flags: flags}, true
+}
+
+// traceParent formats the traceparent header value for outgoing requests.
+func (tc traceContext) traceParent() string {
+	return "00-" + tc.traceID + "-" + tc.spanID + "-" + tc.flags
+}
+
+// isHex reports whether s is a lowercase hex string of length n.
+func isHex(s string, n int) bool {
+	if len(s) != n {
+		return false
+	}
+	for _, c := range s {
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+// randomHex returns n random bytes encoded as lowercase hex.
+func randomHex(n int) string {
+	b := make([]byte, n)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// TraceID returns the W3C trace ID of the request.
+func (ctx *Context) TraceID() string {
+	return ctx.trace.traceID
+}
+
+// SpanID returns the span ID generated for the current request.
+func (ctx *Context) SpanID() string {
+	return ctx.trace.spanID
+}
+
+// ParentSpanID returns the caller's span ID from the traceparent header, or empty if none.
+func (ctx *Context) ParentSpanID() string {
+	return ctx.trace.parentSpanID
+}
+
+// TraceParent returns the traceparent header value to propagate to downstream services.
+func (ctx *Context) TraceParent() string {
+	return ctx.trace.traceParent()
+}
+
+// TraceState returns the tracestate header value to propagate to downstream services.
+func (ctx *Context) TraceState() string {
+	return ctx.trace.state
+}
@@ -0,0 +1,23 @@
+package mux
+
+// ResponseTransformer returns the body to send in place of body, e.g. to add hypermedia
+// links or to filter fields based on a ?fields= query parameter.
+// It receives every JSON body sent with encode-based methods (Send, OK, Created...),
+// including ErrorResponse values, and should return body unchanged for types it doesn't handle.
+type ResponseTransformer func(ctx *Context, body any) any
+
+// AddResponseTransformer registers a transformer applied to JSON response bodies before marshaling,
+// in registration order. Transformers run on every response, so they should be cheap and
+// return early for bodies they don't change; reflection-based field filtering of large
+// lists adds up quickly. It should be called before ListenAndServe.
+func (r *router) AddResponseTransformer(fn ResponseTransformer) {
+	r.transformers = append(r.transformers, fn)
+}
+
+// transformBody applies the registered transformers to body in registration order.
+func (r *router) transformBody(ctx *Context, body any) any {
+	for _, transform := range r.transformers {
+		body = transform(ctx, body)
+	}
+	return body
+}
@@ -0,0 +1,115 @@
+package mux
+
+import (
+	"bufio"
+	"net"
+	"net/http"
+)
+
+// responseWriter wraps http.ResponseWriter to record the response status.
+// It also holds the deferred status set by Context.SetStatus, which is used
+// when the response is written without an explicit status.
+type responseWriter struct {
+	http.ResponseWriter
+
+	// status is the status code written to the client, 0 if not written yet.
+	status int
+
+	// pending is the deferred status code set by Context.SetStatus.
+	pending int
+
+	// contentType is the default Content-Type of JSON responses.
+	contentType string
+
+	// compact removes null values and empty objects and arrays from JSON responses.
+	compact bool
+
+	// renameKey converts the keys of JSON responses, nil to keep them as-is.
+	renameKey func(string) string
+
+	// transform applies the router's response transformers to JSON bodies, nil if none.
+	transform func(body any) any
+}
+
+// newResponseWriter wraps w unless it is already wrapped.
+func newResponseWriter(w http.ResponseWriter) *responseWriter {
+	if rw, ok := w.(*responseWriter); ok {
+		return rw
+	}
+	return &responseWriter{ResponseWriter: w}
+}
+
+// WriteHeader records the status code and writes it to the underlying writer.
+func (w *responseWriter) WriteHeader(code int) {
+	if w.status == 0 && code >= http.StatusOK {
+		w.status = code
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+// Write writes the deferred status (or 200 OK) if no status was written yet, then writes b.
+func (w *responseWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.WriteHeader(w.statusOrDefault(0))
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// Status returns the written status code, the deferred one if nothing was written yet, or 0.
+func (w *responseWriter) Status() int {
+	if w.status != 0 {
+		return w.status
+	}
+	return w.pending
+}
+
+// Written reports whether the status code has been written to the client.
+func (w *responseWriter) Written() bool {
+	return w.status != 0
+}
+
+// Flush sends any buffered data to the client, if the underlying writer supports it.
+func (w *responseWriter) Flush() {
+	f, ok := w.ResponseWriter.(http.Flusher)
+	if !ok {
+		return
+	}
+	if w.status == 0 {
+		w.WriteHeader(w.statusOrDefault(0))
+	}
+	f.Flush()
+}
+
+// Hijack lets the caller take over the connection, if the underlying writer supports it.
+func (w *responseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	h, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, http.ErrNotSupported
+	}
+	return h.Hijack()
+}
+
+// Push initiates an HTTP/2 server push, if the underlying writer supports it.
+func (w *responseWriter) Push(target string, opts *http.PushOptions) error {
+	p, ok := w.ResponseWriter.(http.Pusher)
+	if !ok {
+		return http.ErrNotSupported
+	}
+	return p.Push(target, opts)
+}
+
+// Unwrap returns the underlying http.ResponseWriter, used by http.ResponseController.
+func (w *responseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// statusOrDefault returns status if non-zero, otherwise the deferred status or 200 OK.
+func (w *responseWriter) statusOrDefault(status int) int {
+	if status != 0 {
+		return status
+	}
+	if w.pending != 0 {
+		return w.pending
+	}
+	return http.StatusOK
+}
@@ -1,54 +1,534 @@
 package valid
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"reflect"
+	"sort"
 	"strings"
 	"sync"
 
 	"github.com/go-playground/validator/v10"
+
+	"github.com/obadmatar/base/log"
 )
 
 // fieldCache for caching struct field mappings
 var fieldCache sync.Map
 
-var validate *validator.Validate
+// validate is the default validator used by the package-level functions
+var validate *Validator
+
+// fieldNameFunc transforms the field names used as keys in the errors map
+var fieldNameFunc func(structField, tagName string) string
+
+// validationErrorFunc is called for each field error extracted by ExtractFieldErrors
+var validationErrorFunc func(field, tag string)
+
+// verboseMessages makes ExtractFieldErrors name the failed tag of tags without a message
+var verboseMessages bool
 
 type validationErrors = validator.ValidationErrors
 
 type Errors struct {
 	cacheKey string
 	validator.ValidationErrors
+
+	// prefix is prepended to the field names of nested errors, e.g. "[theme]."
+	prefix string
+
+	// nested holds the errors of each value validated by Map
+	nested []Errors
 }
 
+// Warnings maps field names to messages of failed warning validations.
+// Unlike Errors they don't reject the input, e.g. "password is weak but accepted".
+type Warnings map[string]string
+
+// warnTagPrefix marks a validation tag as a non-blocking warning, e.g. `validate:"warn_min=12"`
+const warnTagPrefix = "warn_"
+
+// warnTags are the built-in tags usable as warnings with the warn_ prefix
+var warnTags = []string{
+	"required", "min", "max", "len", "eq", "ne", "gt", "gte", "lt", "lte", "oneof",
+	"email", "url", "uuid", "alpha", "alphanum", "numeric", "contains", "containsany",
+	"excludes", "lowercase", "uppercase",
+}
+
+// enumValues maps enum types to their allowed values
+var enumValues sync.Map
+
 func init() {
-	validate = validator.New(validator.WithRequiredStructEnabled())
+	validate = New()
+}
+
+// Validator wraps a validator instance, so handlers can use request-specific validations
+// (e.g. tenant-scoped uniqueness checks) without changing the package-level default.
+type Validator struct {
+	validate *validator.Validate
+}
+
+// New creates a Validator with the built-in validations of the package (e.g. enum).
+func New() *Validator {
+	v := validator.New(validator.WithRequiredStructEnabled())
+	_ = v.RegisterValidation("enum", validateEnum)
+
+	val := &Validator{validate: v}
+	for _, tag := range warnTags {
+		_ = val.RegisterWarning(tag)
+	}
+	return val
+}
+
+// RegisterWarning registers the warn_ variant of the given tag, e.g. "strong_password"
+// registers "warn_strong_password". Fields failing the warn_ variant are reported as
+// Warnings by StructWithWarnings instead of rejecting the struct.
+// Built-in tags such as min, max or email are registered by New.
+func (v *Validator) RegisterWarning(tag string) error {
+	return v.validate.RegisterValidation(warnTagPrefix+tag, func(fl validator.FieldLevel) bool {
+		rule := tag
+		if fl.Param() != "" {
+			rule += "=" + fl.Param()
+		}
+		return v.validate.Var(fl.Field().Interface(), rule) == nil
+	})
+}
+
+// RegisterWarning registers the warn_ variant of the given tag on the default validator.
+// It should be called during initialization, after registering the tag itself.
+func RegisterWarning(tag string) error {
+	return validate.RegisterWarning(tag)
+}
+
+// RegisterValidation adds a validation function for the given tag.
+func (v *Validator) RegisterValidation(tag string, fn validator.Func) error {
+	return v.validate.RegisterValidation(tag, fn)
+}
+
+// RegisterStructValidation registers a struct-level validation function for the given types.
+func (v *Validator) RegisterStructValidation(fn validator.StructLevelFunc, types ...any) {
+	v.validate.RegisterStructValidation(fn, types...)
+}
+
+// RegisterEnum registers the allowed values of the string-based type T.
+// Fields of type T tagged with `validate:"enum"` must hold one of these values,
+// so the list is defined once and reused by every struct using the type.
+//
+//	type Status string
+//
+//	valid.RegisterEnum(StatusActive, StatusInactive)
+//
+//	type Filter struct {
+//		Status Status `query:"status" validate:"enum"`
+//	}
+//
+// It should be called during initialization.
+func RegisterEnum[T ~string](values ...T) {
+	allowed := make([]string, len(values))
+	for i, v := range values {
+		allowed[i] = string(v)
+	}
+	enumValues.Store(reflect.TypeOf((*T)(nil)).Elem(), allowed)
+}
+
+// validateEnum checks the field value is one of the values registered for its type.
+func validateEnum(fl validator.FieldLevel) bool {
+	allowed, found := enumValues.Load(fl.Field().Type())
+	if !found {
+		return false
+	}
+	value := fl.Field().String()
+	for _, v := range allowed.([]string) {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// RegisterStructValidation registers a struct-level validation function for the given types,
+// for rules spanning multiple fields that tags can't express. Errors reported with
+// StructLevel.ReportError are returned in Errors like tag-based errors; report the struct
+// field name as fieldName so the error key resolves to the field's json or query tag.
+//
+//	type DateRange struct {
+//		Start time.Time `json:"start_date"`
+//		End   time.Time `json:"end_date"`
+//	}
+//
+//	valid.RegisterStructValidation(func(sl validator.StructLevel) {
+//		r := sl.Current().Interface().(DateRange)
+//		if !r.End.After(r.Start) {
+//			sl.ReportError(r.End, "End", "End", "gtfield", "start_date")
+//		}
+//	}, DateRange{})
+//
+// It should be called during initialization.
+func RegisterStructValidation(fn validator.StructLevelFunc, types ...any) {
+	validate.RegisterStructValidation(fn, types...)
 }
 
 // Struct validates a struct using the validator package
+// Failed warn_ tags are ignored, use StructWithWarnings to get them.
 func Struct(s interface{}) error {
+	return validate.Struct(s)
+}
+
+// StructWithWarnings validates a struct like Struct, and also returns the failed warn_ tags
+// as Warnings, which are reported even if the struct is valid.
+func StructWithWarnings(s interface{}) (Warnings, error) {
+	return validate.StructWithWarnings(s)
+}
+
+// Struct validates a struct, returning Errors on validation failure like the package-level Struct.
+func (v *Validator) Struct(s interface{}) error {
+	_, err := v.StructWithWarnings(s)
+	return err
+}
+
+// StructWithWarnings validates a struct, returning Warnings and Errors like the package-level StructWithWarnings.
+func (v *Validator) StructWithWarnings(s interface{}) (Warnings, error) {
+	return v.StructWithWarningsCtx(context.Background(), s)
+}
+
+// StructCtx validates a struct like Struct, returning the context error if ctx is done,
+// e.g. when the client of a batch endpoint disconnected. ctx is passed to context-aware validations.
+// A slice or array of structs is validated element by element, checking ctx in between,
+// with field errors keyed by the element index, e.g. "[3].email". A single struct is only
+// checked before and after its validation, which the validator runs to completion, so it
+// can't be interrupted midway even with large nested slices.
+func StructCtx(ctx context.Context, s interface{}) error {
+	return validate.StructCtx(ctx, s)
+}
+
+// StructWithWarningsCtx validates a struct like StructCtx, and also returns the failed warn_ tags
+// as Warnings like StructWithWarnings.
+func StructWithWarningsCtx(ctx context.Context, s interface{}) (Warnings, error) {
+	return validate.StructWithWarningsCtx(ctx, s)
+}
+
+// StructCtx validates a struct or a slice of structs, like the package-level StructCtx.
+func (v *Validator) StructCtx(ctx context.Context, s interface{}) error {
+	_, err := v.StructWithWarningsCtx(ctx, s)
+	return err
+}
+
+// StructWithWarningsCtx validates a struct or a slice of structs, returning Warnings and Errors
+// like the package-level StructWithWarningsCtx.
+func (v *Validator) StructWithWarningsCtx(ctx context.Context, s interface{}) (warnings Warnings, err error) {
+	defer v.recoverValidation(s, &err)
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	rv := reflect.ValueOf(s)
+	if rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array {
+		return v.sliceWithWarnings(ctx, rv)
+	}
+
 	// Generate or retrieve the cache key based on struct
 	key := cacheTypeFields(s)
 
-	// Perform validation
-	err := validate.Struct(s)
+	warnings, err = v.structWithKey(ctx, s, key)
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return nil, ctxErr
+	}
+	return warnings, err
+}
+
+// sliceWithWarnings validates each struct element of the slice or array rv, checking ctx
+// before each element. Field errors and warnings are keyed by the element index, e.g. "[3].email".
+func (v *Validator) sliceWithWarnings(ctx context.Context, rv reflect.Value) (Warnings, error) {
+	var (
+		errs     Errors
+		warnings Warnings
+	)
+
+	for i := 0; i < rv.Len(); i++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		// Only non-nil struct elements can be validated
+		elem := rv.Index(i)
+		for elem.Kind() == reflect.Interface || elem.Kind() == reflect.Ptr {
+			if elem.IsNil() {
+				break
+			}
+			elem = elem.Elem()
+		}
+		if elem.Kind() != reflect.Struct {
+			continue
+		}
+
+		s := elem.Interface()
+		warns, err := v.structWithKey(ctx, s, cacheTypeFields(s))
+		for field, msg := range warns {
+			if warnings == nil {
+				warnings = make(Warnings)
+			}
+			warnings[fmt.Sprintf("[%d].%s", i, field)] = msg
+		}
+		if err == nil {
+			continue
+		}
+
+		var vrr Errors
+		if !errors.As(err, &vrr) {
+			return nil, err
+		}
+
+		vrr.prefix = fmt.Sprintf("[%d].", i)
+		errs.nested = append(errs.nested, vrr)
+		errs.ValidationErrors = append(errs.ValidationErrors, vrr.ValidationErrors...)
+	}
+
+	if len(errs.nested) == 0 {
+		return warnings, nil
+	}
+
+	return warnings, errs
+}
+
+// StructExcept validates a struct like Struct, skipping the given fields, e.g. the fields of a later
+// step of a multi-step form. Fields are Go struct field names, namespaced relative to s for nested
+// fields, e.g. "Address.City".
+func StructExcept(s interface{}, fields ...string) error {
+	return validate.StructExcept(s, fields...)
+}
+
+// StructPartial validates only the given fields of a struct, like Struct.
+// Fields are Go struct field names, namespaced relative to s for nested fields, e.g. "Address.City".
+func StructPartial(s interface{}, fields ...string) error {
+	return validate.StructPartial(s, fields...)
+}
+
+// StructExcept validates a struct skipping the given fields, like the package-level StructExcept.
+func (v *Validator) StructExcept(s interface{}, fields ...string) (err error) {
+	defer v.recoverValidation(s, &err)
+
+	key := cacheTypeFields(s)
+	_, err = splitWarnings(v.validate.StructExcept(s, fields...), key)
+	return err
+}
+
+// StructPartial validates only the given fields of a struct, like the package-level StructPartial.
+func (v *Validator) StructPartial(s interface{}, fields ...string) (err error) {
+	defer v.recoverValidation(s, &err)
+
+	key := cacheTypeFields(s)
+	_, err = splitWarnings(v.validate.StructPartial(s, fields...), key)
+	return err
+}
+
+// Compile prepares the validation of the struct type T once, returning a function validating
+// values of T. It only skips the field-name cache lookup of Struct, a small gain for hot paths
+// validating the same type many times. It returns the same Errors as Struct, and uses the
+// default validator.
+// T must be a struct type. It should be called during initialization.
+func Compile[T any]() func(*T) error {
+	v := validate
+
+	// Build the field names cache and warm up the validator's own struct cache
+	var zero T
+	key := cacheTypeFields(&zero)
+	_, _ = v.StructWithWarnings(&zero)
+
+	return func(s *T) (err error) {
+		defer v.recoverValidation(s, &err)
+
+		_, err = v.structWithKey(context.Background(), s, key)
+		return err
+	}
+}
+
+// recoverValidation recovers from validator panics caused by misconfigured tags
+// (e.g. a tag applied to an unsupported type), setting *err instead.
+// It must be deferred directly.
+func (v *Validator) recoverValidation(s interface{}, err *error) {
+	rec := recover()
+	if rec == nil {
+		return
+	}
+
+	field, tag := v.panickingField(s)
+	if field == "" {
+		log.Error("valid: validator panicked, check struct validation tags", "struct", fmt.Sprintf("%T", s), "error", rec)
+		*err = fmt.Errorf("valid: failed to validate %T: %v", s, rec)
+		return
+	}
+
+	log.Error("valid: validator panicked, check struct validation tags", "struct", fmt.Sprintf("%T", s), "field", field, "tag", tag, "error", rec)
+	*err = fmt.Errorf("valid: failed to validate %T: field %s with tag %q: %v", s, field, tag, rec)
+}
+
+// panickingField returns the namespace (e.g. "Address.Zip") and validation tag of the field
+// of the struct s whose validation panics, by validating the fields one at a time.
+// It returns empty strings if s is not a struct or no single field panics.
+func (v *Validator) panickingField(s interface{}) (field, tag string) {
+	t := reflect.TypeOf(s)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return "", ""
+	}
+	return v.findPanickingField(s, t, "", make(map[reflect.Type]bool))
+}
+
+// findPanickingField looks for the panicking field among the fields of t, the type of s or of
+// one of its nested structs at the given namespace prefix. Nested struct fields are searched
+// before the field holding them, to report the innermost culprit. seen holds the struct types
+// being searched, so recursive types are only searched once.
+func (v *Validator) findPanickingField(s interface{}, t reflect.Type, prefix string, seen map[reflect.Type]bool) (field, tag string) {
+	seen[t] = true
+	defer delete(seen, t)
+
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		name := prefix + sf.Name
+
+		ft := sf.Type
+		for ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		if ft.Kind() == reflect.Struct && !seen[ft] {
+			if field, tag := v.findPanickingField(s, ft, name+".", seen); field != "" {
+				return field, tag
+			}
+		}
+
+		if v.partialPanics(s, name) {
+			return name, sf.Tag.Get("validate")
+		}
+	}
+	return "", ""
+}
+
+// partialPanics reports whether validating only the named field of s panics.
+func (v *Validator) partialPanics(s interface{}, field string) (panicked bool) {
+	defer func() {
+		if recover() != nil {
+			panicked = true
+		}
+	}()
+
+	_ = v.validate.StructPartial(s, field)
+	return false
+}
+
+// structWithKey validates a struct whose field names are cached under key,
+// separating the failed warn_ tags from the hard failures.
+func (v *Validator) structWithKey(ctx context.Context, s interface{}, key string) (warnings Warnings, err error) {
+	return splitWarnings(v.validate.StructCtx(ctx, s), key)
+}
+
+// splitWarnings separates the failed warn_ tags of the validator error err from the hard
+// failures, returning them as Warnings and Errors whose field names are cached under key.
+func splitWarnings(err error, key string) (Warnings, error) {
 	if err == nil {
 		// No validation errors, return nil
-		return nil
+		return nil, nil
 	}
 
 	// If validation errors exist, process them
 	var vrr validationErrors
 	if !errors.As(err, &vrr) {
 		// Un-known error, return as is
-		return err
+		return nil, err
+	}
+
+	// Separate warnings from hard failures
+	var failures, warns validationErrors
+	for _, e := range vrr {
+		if strings.HasPrefix(e.Tag(), warnTagPrefix) {
+			warns = append(warns, e)
+		} else {
+			failures = append(failures, e)
+		}
+	}
+
+	var warnings Warnings
+	if len(warns) > 0 {
+		warnings = ExtractFieldErrors(Errors{cacheKey: key, ValidationErrors: warns})
+	}
+
+	if len(failures) == 0 {
+		return warnings, nil
 	}
 
 	// Return an Errors struct containing the cache key and validation errors
-	return Errors{
+	return warnings, Errors{
 		cacheKey:         key,
-		ValidationErrors: vrr,
+		ValidationErrors: failures,
+	}
+}
+
+// Map validates each struct value of the map m, like Struct, for payloads with dynamic keys.
+// Field errors are keyed by the map key, e.g. "[theme].color". Non-struct values are skipped.
+func Map(m any) error {
+	return validate.Map(m)
+}
+
+// Map validates each struct value of the map m, returning Errors on validation failure like the package-level Map.
+func (v *Validator) Map(m any) error {
+	rv := reflect.ValueOf(m)
+	if rv.Kind() == reflect.Ptr {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Map {
+		return fmt.Errorf("valid: Map expects a map, got %T", m)
+	}
+
+	// Sort keys so errors are reported in a stable order
+	keys := rv.MapKeys()
+	sort.Slice(keys, func(i, j int) bool {
+		return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+	})
+
+	var errs Errors
+	for _, key := range keys {
+		value := rv.MapIndex(key)
+
+		// Only non-nil struct values can be validated
+		for value.Kind() == reflect.Interface || value.Kind() == reflect.Ptr {
+			if value.IsNil() {
+				break
+			}
+			value = value.Elem()
+		}
+		if value.Kind() != reflect.Struct {
+			continue
+		}
+
+		err := v.Struct(value.Interface())
+		if err == nil {
+			continue
+		}
+
+		var vrr Errors
+		if !errors.As(err, &vrr) {
+			return err
+		}
+
+		vrr.prefix = fmt.Sprintf("[%v].", key.Interface())
+		errs.nested = append(errs.nested, vrr)
+		errs.ValidationErrors = append(errs.ValidationErrors, vrr.ValidationErrors...)
+	}
+
+	if len(errs.nested) == 0 {
+		return nil
 	}
+
+	return errs
 }
 
 func cacheTypeFields(s interface{}) string {
@@ -65,11 +545,7 @@ func cacheTypeFields(s interface{}) string {
 
 	// Build fields map
 	fieldsMap := make(map[string]string)
-	for i := 0; i < t.NumField(); i++ {
-		field := t.Field(i)
-		value := fieldTagValue(field)
-		fieldsMap[field.Name] = value
-	}
+	collectTypeFields(t, fieldsMap)
 
 	// Cache the result
 	fieldCache.Store(cacheKey, fieldsMap)
@@ -77,10 +553,76 @@ func cacheTypeFields(s interface{}) string {
 	return cacheKey
 }
 
+// SetFieldNameFunc sets a function transforming the field names used as keys in the map
+// returned by ExtractFieldErrors, e.g. to enforce snake_case on the wire.
+// It receives the struct field name and the resolved tag name (json, query, header or lowercased field name).
+// Pass nil to use the tag name as-is. It should be called during initialization.
+func SetFieldNameFunc(fn func(structField, tagName string) string) {
+	fieldNameFunc = fn
+}
+
+// collectTypeFields adds the tag value of each field of t to fieldsMap.
+// Fields of anonymous embedded structs are flattened into the map,
+// outer fields take precedence over embedded fields with the same name.
+func collectTypeFields(t reflect.Type, fieldsMap map[string]string) {
+	var embedded []reflect.Type
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		// Embedded structs without a json tag are flattened like encoding/json does
+		ft := field.Type
+		if ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		if field.Anonymous && ft.Kind() == reflect.Struct && field.Tag.Get("json") == "" {
+			embedded = append(embedded, ft)
+			continue
+		}
+
+		fieldsMap[field.Name] = fieldTagValue(field)
+	}
+
+	for _, et := range embedded {
+		embeddedMap := make(map[string]string)
+		collectTypeFields(et, embeddedMap)
+		for name, value := range embeddedMap {
+			if _, found := fieldsMap[name]; !found {
+				fieldsMap[name] = value
+			}
+		}
+	}
+}
+
+// SetVerboseMessages makes ExtractFieldErrors report the failed tag and its param for tags
+// without a friendly message, e.g. "failed validation: excludesall(@)" instead of "is invalid".
+// It is meant for development, the terse default being better suited to end users.
+// It should be called during initialization.
+func SetVerboseMessages(verbose bool) {
+	verboseMessages = verbose
+}
+
+// OnValidationError sets a function called for each field error extracted by ExtractFieldErrors,
+// with the resolved field name and the failed tag, e.g. to count which fields users fail most.
+// Pass nil to disable it. It should be called during initialization.
+func OnValidationError(fn func(field, tag string)) {
+	validationErrorFunc = fn
+}
+
 func ExtractFieldErrors(vrr Errors) map[string]string {
 	errorMap := make(map[string]string)
 	fieldMap := make(map[string]string)
 
+	// Errors returned by Map are keyed by the map key of each value
+	if len(vrr.nested) > 0 {
+		for _, nested := range vrr.nested {
+			for field, msg := range ExtractFieldErrors(nested) {
+				errorMap[nested.prefix+field] = msg
+			}
+		}
+		return errorMap
+	}
+
 	// Check if struct type is already cached
 	if cached, found := fieldCache.Load(vrr.cacheKey); found {
 		fieldMap = cached.(map[string]string)
@@ -90,7 +632,8 @@ func ExtractFieldErrors(vrr Errors) map[string]string {
 	for _, e := range vrr.ValidationErrors {
 		var errorMsg string
 
-		switch e.Tag() {
+		// Warnings use the messages of their underlying tag
+		switch strings.TrimPrefix(e.Tag(), warnTagPrefix) {
 		case "required":
 			errorMsg = "is required"
 		case "email":
@@ -129,6 +672,11 @@ func ExtractFieldErrors(vrr Errors) map[string]string {
 			errorMsg = "must be a valid datetime"
 		case "oneof":
 			errorMsg = "must be one of: [" + strings.Join(strings.Split(e.Param(), " "), ",") + "]"
+		case "enum":
+			errorMsg = "is invalid"
+			if allowed, found := enumValues.Load(e.Type()); found {
+				errorMsg = "must be one of: [" + strings.Join(allowed.([]string), ",") + "]"
+			}
 		// Comparison-based tags
 		case "eq", "eqfield":
 			errorMsg = "must be equal to " + e.Param()
@@ -196,6 +744,12 @@ func ExtractFieldErrors(vrr Errors) map[string]string {
 			errorMsg = "must be unique"
 		default:
 			errorMsg = "is invalid"
+			if verboseMessages {
+				errorMsg = "failed validation: " + e.Tag()
+				if e.Param() != "" {
+					errorMsg += "(" + e.Param() + ")"
+				}
+			}
 		}
 
 		// Get the field name based on available tag
@@ -205,12 +759,22 @@ func ExtractFieldErrors(vrr Errors) map[string]string {
 			fieldName = strings.ToLower(e.Field())
 		}
 
+		// Transform the field name if applicable
+		if fieldNameFunc != nil {
+			fieldName = fieldNameFunc(e.StructField(), fieldName)
+		}
+
+		// Report the field error if applicable
+		if validationErrorFunc != nil {
+			validationErrorFunc(fieldName, e.Tag())
+		}
+
 		errorMap[fieldName] = errorMsg
 	}
 	return errorMap
 }
 
-// fieldTagValue returns the appropriate tag value (json, query, or field name) based on the tag availability.
+// fieldTagValue returns the appropriate tag value (json, query, header, path, or field name) based on the tag availability.
 func fieldTagValue(field reflect.StructField) string {
 	// tag: json
 	if value := field.Tag.Get("json"); value != "" && value != "-" {
@@ -220,6 +784,14 @@ func fieldTagValue(field reflect.StructField) string {
 	if value := field.Tag.Get("query"); value != "" && value != "-" {
 		return strings.Split(value, ",")[0]
 	}
+	// tag: header
+	if value := field.Tag.Get("header"); value != "" && value != "-" {
+		return strings.Split(value, ",")[0]
+	}
+	// tag: path
+	if value := field.Tag.Get("path"); value != "" && value != "-" {
+		return strings.Split(value, ",")[0]
+	}
 
 	// Fallback to the field name
 	return strings.ToLower(field.Name)
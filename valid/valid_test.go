@@ -0,0 +1,110 @@
+package valid
+
+import (
+	"errors"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// misconfigured applies dive to a string, making the validator panic.
+type misconfigured struct {
+	Name string `json:"name" validate:"required"`
+	Tags string `json:"tags" validate:"dive,required"`
+}
+
+type misconfiguredOuter struct {
+	Inner misconfigured `json:"inner" validate:"required"`
+}
+
+func TestStructRecoversFromPanic(t *testing.T) {
+	tests := []struct {
+		name  string
+		value any
+		field string
+	}{
+		{"struct", &misconfigured{Name: "a", Tags: "b"}, "Tags"},
+		{"nested struct", &misconfiguredOuter{Inner: misconfigured{Name: "a", Tags: "b"}}, "Inner.Tags"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Struct(tt.value)
+			if err == nil {
+				t.Fatal("Struct returned nil, want an error")
+			}
+			if _, ok := err.(Errors); ok {
+				t.Fatalf("Struct returned validation Errors %v, want a configuration error", err)
+			}
+
+			msg := err.Error()
+			if !strings.Contains(msg, "field "+tt.field+" ") || !strings.Contains(msg, `"dive,required"`) {
+				t.Errorf("error %q does not identify field %s and its tag", msg, tt.field)
+			}
+		})
+	}
+}
+
+func TestCompileRecoversFromPanic(t *testing.T) {
+	validate := Compile[misconfigured]()
+
+	err := validate(&misconfigured{Name: "a", Tags: "b"})
+	if err == nil || !strings.Contains(err.Error(), "field Tags ") {
+		t.Errorf("compiled validation error = %v, want one identifying field Tags", err)
+	}
+}
+
+type Pagination struct {
+	Page    int `query:"page" validate:"min=1"`
+	PerPage int `query:"per_page" validate:"max=100"`
+}
+
+type listUsersRequest struct {
+	Pagination
+	Status string `query:"status" validate:"required"`
+}
+
+func TestExtractFieldErrorsEmbeddedStruct(t *testing.T) {
+	err := Struct(&listUsersRequest{Pagination: Pagination{Page: 0, PerPage: 500}})
+
+	var vrr Errors
+	if !errors.As(err, &vrr) {
+		t.Fatalf("Struct returned %v, want validation Errors", err)
+	}
+
+	got := slices.Sorted(maps.Keys(ExtractFieldErrors(vrr)))
+	want := []string{"page", "per_page", "status"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ExtractFieldErrors keys = %v, want %v", got, want)
+	}
+}
+
+type benchmarkUser struct {
+	Name  string `json:"name" validate:"required,min=2"`
+	Email string `json:"email" validate:"required,email"`
+}
+
+func BenchmarkStruct(b *testing.B) {
+	user := &benchmarkUser{Name: "Ada", Email: "ada@example.com"}
+
+	b.ReportAllocs()
+	for b.Loop() {
+		if err := Struct(user); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkCompile(b *testing.B) {
+	validate := Compile[benchmarkUser]()
+	user := &benchmarkUser{Name: "Ada", Email: "ada@example.com"}
+
+	b.ReportAllocs()
+	for b.Loop() {
+		if err := validate(user); err != nil {
+			b.Fatal(err)
+		}
+	}
+}